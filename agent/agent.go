@@ -3,26 +3,41 @@ package agent
 //go:generate protoc -I ../proto/ ../proto/profile.proto --go_out=plugins=grpc:../proto
 
 import (
-	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"os"
 	"os/user"
+	"regexp"
 	"runtime"
+	"runtime/debug"
 	"runtime/pprof"
 	"runtime/trace"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/chanchal1987/grpc-profile/internal/tlsconfig"
 	"github.com/chanchal1987/grpc-profile/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	timestamppb "github.com/golang/protobuf/ptypes/timestamp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 var lookupStr = map[proto.LookupProfile]string{
@@ -31,46 +46,185 @@ var lookupStr = map[proto.LookupProfile]string{
 	proto.LookupProfile_profileTypeBlock:        "block",
 	proto.LookupProfile_profileTypeThreadCreate: "threadcreate",
 	proto.LookupProfile_profileTypeGoRoutine:    "goroutine",
+	proto.LookupProfile_profileTypeAllocs:       "allocs",
 }
 
+var nonLookupStr = map[proto.NonLookupProfile]string{
+	proto.NonLookupProfile_profileTypeCPU:   "cpu",
+	proto.NonLookupProfile_profileTypeTrace: "trace",
+}
+
+// profileMethods lists the RPCs which collect a profile from the running process
+var profileMethods = map[string]bool{
+	"/proto.ProfileService/LookupProfile":     true,
+	"/proto.ProfileService/NonLookupProfile":  true,
+	"/proto.ProfileService/ContinuousProfile": true,
+	"/proto.ProfileService/Snapshot":          true,
+}
+
+// defaultChunkSize is the maximum size of the content of a `proto.FileChunk` or a `proto.ProfileFrame`, unless it is
+// changed with `ServerWithChunkSize`
+const defaultChunkSize = 32 * 1024
+
+// The runtime has no getter for the CPU and block profile rates, so the values set through any agent of the process
+// are tracked here. They are shared by all agents, so an agent created after another one changed a rate records the
+// changed rate as its initial value
+var (
+	variableMutex    sync.Mutex
+	cpuProfileRate   int
+	blockProfileRate int
+)
+
 // Agent will store GRPC Profile Agent instance. We can create a instance of the agent using `NewAgent()` function
 type Agent struct {
 	listen        net.Listener
 	server        *grpc.Server
 	serverOptions []grpc.ServerOption
+
+	// gcPercent is the GC percent set through the agent or GOGC, it is used on runtimes without the GOGC metric
+	gcPercent int
+
+	// initialValues stores the value of every variable when the agent was created, `Reset()` restores them
+	initialValues map[proto.ProfileVariable]int32
+
+	cpuBackend     CPUBackend
+	maxProfileSize int64
+	chunkSize      int
+
+	// maxProfileDuration caps the duration of non lookup profiles, longer ones are clamped or rejected
+	maxProfileDuration   time.Duration
+	clampProfileDuration bool
+
+	// running stores which non lookup profile types are running
+	runningMutex sync.Mutex
+	running      map[proto.NonLookupProfile]bool
+
+	environRedaction *regexp.Regexp
+	omitEnviron      bool
+
+	// Optional HTTP listeners, every request has to pass httpChecks
+	pprofHTTP   *httpEndpoint
+	metricsHTTP *httpEndpoint
+	httpChecks  []func(*http.Request) error
+
+	logger Logger
+
+	// profileQueues serialize the non lookup profiles of each type, if enabled
+	profileQueues map[proto.NonLookupProfile]*profileQueue
+
+	rpcStats rpcStats
+
+	health *healthServer
 }
 
 // NewAgent function will create a GRPC Profile Agent instance
 func NewAgent(options ...*ServerOption) (agent *Agent, err error) {
-	agent = &Agent{}
+	agent = &Agent{environRedaction: defaultEnvironRedaction, health: newHealthServer(), gcPercent: envGCPercent()}
 	err = agent.SetOptions(options...)
 	if err != nil {
 		return
 	}
+
+	agent.initialValues = make(map[proto.ProfileVariable]int32)
+	for variable := range proto.ProfileVariable_name {
+		value, err := agent.Get(context.Background(), &proto.GetProfileInputType{Variable: proto.ProfileVariable(variable)})
+		if err != nil {
+			return nil, err
+		}
+		agent.initialValues[proto.ProfileVariable(variable)] = value.Value
+	}
 	return
 }
 
-// Start function will start GRPC Profile Agent
-func (agent *Agent) Start(serverAddress string) (addr *net.TCPAddr, err error) {
+// Start function will start GRPC Profile Agent. The result of serving is delivered on the returned channel once the
+// agent stops serving: nil after `Stop()` or `GracefulStop()`, the error otherwise
+func (agent *Agent) Start(serverAddress string) (addr *net.TCPAddr, errs <-chan error, err error) {
+	return agent.StartContext(context.Background(), serverAddress)
+}
+
+// StartContext function will start GRPC Profile Agent like `Start()` and stop it with `GracefulStop()` once ctx is
+// cancelled, so the agent can be tied to the lifetime of the application
+func (agent *Agent) StartContext(ctx context.Context, serverAddress string) (addr *net.TCPAddr, errs <-chan error, err error) {
 	agent.listen, err = net.Listen("tcp", serverAddress)
 	if err != nil {
 		return
 	}
 	addr = agent.listen.Addr().(*net.TCPAddr)
-	agent.server = grpc.NewServer(agent.serverOptions...)
+	err = agent.startHTTP()
+	if err != nil {
+		_ = agent.listen.Close()
+		return nil, nil, err
+	}
+	serverOptions := append(agent.recoverInterceptors(), agent.statsInterceptors()...)
+	agent.server = grpc.NewServer(append(serverOptions, agent.serverOptions...)...)
 	proto.RegisterProfileServiceServer(agent.server, agent)
+	healthpb.RegisterHealthServer(agent.server, agent.health)
 	reflection.Register(agent.server)
 
+	serveErr := make(chan error, 1)
+	served := make(chan struct{})
 	go func() {
-		_ = agent.server.Serve(agent.listen)
+		err := agent.server.Serve(agent.listen)
+		if err == grpc.ErrServerStopped {
+			// The agent was stopped before it started serving
+			err = nil
+		}
+		serveErr <- err
+		close(serveErr)
+		close(served)
 	}()
+	if done := ctx.Done(); done != nil {
+		go func() {
+			select {
+			case <-done:
+				agent.GracefulStop(0)
+			case <-served:
+			}
+		}()
+	}
 
-	return
+	return addr, serveErr, nil
+}
+
+// Addr function will return the address the agent is listening on, or nil if it is not started. With port 0 passed
+// to `Start()`, e.g. "127.0.0.1:0", it reports the port assigned by the operating system
+func (agent *Agent) Addr() *net.TCPAddr {
+	if agent.listen == nil {
+		return nil
+	}
+	return agent.listen.Addr().(*net.TCPAddr)
 }
 
 // Stop function will stop GRPC Profile Agent
 func (agent *Agent) Stop() {
 	agent.server.Stop()
+	agent.stopHTTP(false, 0)
+}
+
+// GracefulStop function will stop GRPC Profile Agent after the running RPCs, e.g. profile and binary dump streams,
+// are complete. New RPCs are rejected meanwhile. If timeout is positive and the running RPCs are not complete in time,
+// the agent is stopped the same way as `Stop()`
+func (agent *Agent) GracefulStop(timeout time.Duration) {
+	defer agent.stopHTTP(true, timeout)
+	if timeout <= 0 {
+		agent.server.GracefulStop()
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		agent.server.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		agent.server.Stop()
+		<-done
+	}
 }
 
 // SetOption function will be used to set `ServerOption` to GRPC Profile Agent
@@ -81,14 +235,19 @@ func (agent *Agent) SetOption(option *ServerOption) error {
 	if option.error != nil {
 		return option.error
 	}
-	agent.serverOptions = append(agent.serverOptions, option.option)
+	if option.option != nil {
+		agent.serverOptions = append(agent.serverOptions, option.option)
+	}
+	if option.apply != nil {
+		option.apply(agent)
+	}
 	return nil
 }
 
 // SetOptions function will be used to set `ServerOption`s to GRPC Profile Agent
 func (agent *Agent) SetOptions(options ...*ServerOption) (err error) {
 	for _, option := range options {
-		err = agent.SetOptions(option)
+		err = agent.SetOption(option)
 		if err != nil {
 			return
 		}
@@ -99,38 +258,214 @@ func (agent *Agent) SetOptions(options ...*ServerOption) (err error) {
 // ServerOption will create a Option for the GRPC Profile Agent
 type ServerOption struct {
 	option grpc.ServerOption
+	apply  func(*Agent)
 	error  error
 }
 
+// WithMaxProfileSize function will create a GRPC Profile Agent option to abort any profile or binary dump stream
+// larger than size bytes. The client receives a `codes.ResourceExhausted` error with an `errdetails.ErrorInfo`
+// detail carrying ReasonProfileTooLarge
+func WithMaxProfileSize(size int64) *ServerOption {
+	if size <= 0 {
+		return &ServerOption{error: errors.New("maximum profile size must be positive")}
+	}
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.maxProfileSize = size
+	}}
+}
+
+// ServerWithMaxProfileDuration function will create a GRPC Profile Agent option to cap the duration of CPU and trace
+// profiles, including every profile of a continuous profile, to d. Longer requests run for d if clamp is set and are
+// rejected with `codes.InvalidArgument` otherwise. The duration is unlimited by default
+func ServerWithMaxProfileDuration(d time.Duration, clamp bool) *ServerOption {
+	if d <= 0 {
+		return &ServerOption{error: errors.New("maximum profile duration must be positive")}
+	}
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.maxProfileDuration = d
+		agent.clampProfileDuration = clamp
+	}}
+}
+
+// profileDuration will return the duration a non lookup profile requested for d runs for
+func (agent *Agent) profileDuration(d time.Duration) (time.Duration, error) {
+	if agent.maxProfileDuration <= 0 || d <= agent.maxProfileDuration {
+		return d, nil
+	}
+	if agent.clampProfileDuration {
+		return agent.maxProfileDuration, nil
+	}
+	return 0, status.Errorf(codes.InvalidArgument, "duration %v exceeds the maximum of %v", d, agent.maxProfileDuration)
+}
+
+// ServerWithChunkSize function will create a GRPC Profile Agent option to stream profiles and binary dumps in messages
+// of up to n bytes instead of 32 KiB. Messages larger than 4 MB need `ServerMaxSendMsgSize` and a client with
+// `DialMaxRecvMsgSize`
+func ServerWithChunkSize(n int) *ServerOption {
+	if n <= 0 {
+		return &ServerOption{error: errors.New("chunk size must be positive")}
+	}
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.chunkSize = n
+	}}
+}
+
+// ServerKeepaliveEnforcement function will create a GRPC Profile Agent option to accept keepalive pings from clients
+// as often as every minTime, and while no RPC is running if permitWithoutStream is set. Clients pinging more often are
+// disconnected. By default pings more frequent than every 5 minutes are rejected
+func ServerKeepaliveEnforcement(minTime time.Duration, permitWithoutStream bool) *ServerOption {
+	return &ServerOption{option: grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             minTime,
+		PermitWithoutStream: permitWithoutStream,
+	})}
+}
+
+// ServerMaxRecvMsgSize function will create a GRPC Profile Agent option to accept messages up to n bytes from clients
+// instead of the gRPC default of 4 MB
+func ServerMaxRecvMsgSize(n int) *ServerOption {
+	if n <= 0 {
+		return &ServerOption{error: errors.New("maximum message size must be positive")}
+	}
+	return &ServerOption{option: grpc.MaxRecvMsgSize(n)}
+}
+
+// ServerMaxSendMsgSize function will create a GRPC Profile Agent option to send messages up to n bytes to clients
+func ServerMaxSendMsgSize(n int) *ServerOption {
+	if n <= 0 {
+		return &ServerOption{error: errors.New("maximum message size must be positive")}
+	}
+	return &ServerOption{option: grpc.MaxSendMsgSize(n)}
+}
+
 // ServerAuthTypeInsecure function will create a Insecure Auth type GRPC Profile Agent option
 func ServerAuthTypeInsecure() *ServerOption {
 	return nil
 }
 
 // ServerAuthTypeTLS function will create a TLS Secure Auth type GRPC Profile Agent option
-func ServerAuthTypeTLS(certFile, keyFile string) *ServerOption {
-	cred, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+func ServerAuthTypeTLS(certFile, keyFile string, options ...TLSOption) *ServerOption {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return &ServerOption{error: err}
+	}
+	return &ServerOption{option: grpc.Creds(credentials.NewTLS(tlsconfig.New(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}, options)))}
+}
+
+// ServerAuthTypeMutualTLS function will create a mutual TLS Auth type GRPC Profile Agent option. Clients must present
+// a certificate signed by one of the CAs in clientCAFile
+func ServerAuthTypeMutualTLS(certFile, keyFile, clientCAFile string, options ...TLSOption) *ServerOption {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return &ServerOption{error: err}
+	}
+	clientCAs, err := tlsconfig.LoadCertPool(clientCAFile)
 	if err != nil {
 		return &ServerOption{error: err}
 	}
-	return &ServerOption{option: grpc.Creds(cred)}
+	return &ServerOption{option: grpc.Creds(credentials.NewTLS(tlsconfig.New(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}, options)))}
 }
 
 type grpcStreamWriter struct {
 	Stream interface{ Send(*proto.FileChunk) error }
+
+	// limit is the maximum number of bytes allowed to be sent, 0 means unlimited
+	limit   int64
+	written int64
+	// Writes are batched into chunks of chunkSize bytes, `Flush()` sends the last partial chunk
+	chunkSize int
+	buf       []byte
+	// err is the first error returned by Write. The CPU profiler ignores write errors, so it is checked after the
+	// profile is stopped
+	err error
+}
+
+func (agent *Agent) newStreamWriter(stream interface{ Send(*proto.FileChunk) error }) *grpcStreamWriter {
+	return &grpcStreamWriter{Stream: stream, limit: agent.maxProfileSize, chunkSize: agent.streamChunkSize()}
+}
+
+// streamChunkSize will return the maximum size of the content of a streamed message
+func (agent *Agent) streamChunkSize() int {
+	if agent.chunkSize > 0 {
+		return agent.chunkSize
+	}
+	return defaultChunkSize
 }
 
 func (w *grpcStreamWriter) Write(bytes []byte) (n int, err error) {
-	for _, b := range bytes {
-		err = w.Stream.Send(&proto.FileChunk{Content: []byte{b}})
+	if w.err != nil {
+		return 0, w.err
+	}
+	defer func() {
+		w.written += int64(n)
 		if err != nil {
-			return
+			w.err = err
+		}
+	}()
+	if w.limit > 0 && w.written+int64(len(bytes)) > w.limit {
+		return 0, profileTooLarge(w.limit)
+	}
+	for len(bytes) > 0 {
+		if w.buf == nil {
+			w.buf = make([]byte, 0, w.chunkSize)
+		}
+		copied := copy(w.buf[len(w.buf):cap(w.buf)], bytes)
+		w.buf = w.buf[:len(w.buf)+copied]
+		bytes = bytes[copied:]
+		n += copied
+		if len(w.buf) == cap(w.buf) {
+			err = w.send()
+			if err != nil {
+				return
+			}
 		}
-		n++
 	}
 	return
 }
 
+// profileTooLarge will return the error of a profile stream aborted because it exceeds limit bytes
+func profileTooLarge(limit int64) error {
+	return statusWithReason(codes.ResourceExhausted, ReasonProfileTooLarge,
+		fmt.Sprintf("profile exceeds the size limit of %d bytes", limit),
+		map[string]string{"limit": strconv.FormatInt(limit, 10)})
+}
+
+// send will send the buffered chunk. A new buffer is allocated for the next chunk, as a sent message must not be
+// modified
+func (w *grpcStreamWriter) send() error {
+	err := w.Stream.Send(&proto.FileChunk{Content: w.buf})
+	w.buf = nil
+	return err
+}
+
+// Flush will send the buffered partial chunk and return the first error of the writer
+func (w *grpcStreamWriter) Flush() error {
+	if w.err == nil && len(w.buf) > 0 {
+		w.err = w.send()
+	}
+	return w.err
+}
+
+// profileWriter will return the writer to write a profile into and a function to call once the profile is complete.
+// If compress is set the profile is gzip compressed before it is sent
+func profileWriter(writer *grpcStreamWriter, compress bool) (io.Writer, func() error) {
+	if !compress {
+		return writer, writer.Flush
+	}
+	gz := gzip.NewWriter(writer)
+	return gz, func() error {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+		return writer.Flush()
+	}
+}
+
 // Ping function will be used to test the connectivity to the server from client.
 // This function will always return a response contains the word "pong"
 func (agent *Agent) Ping(context.Context, *empty.Empty) (*proto.StringType, error) {
@@ -155,6 +490,37 @@ func getGroupName(id int) (string, error) {
 
 // GetInfo function will get the current information about the server.
 func (agent *Agent) GetInfo(context.Context, *empty.Empty) (*proto.InfoType, error) {
+	return agent.info(), nil
+}
+
+// WatchInfo function will stream the current information about the server every interval until the client cancels
+func (agent *Agent) WatchInfo(inputType *proto.WatchInfoInputType, infoServer proto.ProfileService_WatchInfoServer) error {
+	interval, err := ptypes.Duration(inputType.Interval)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if interval <= 0 {
+		return status.Error(codes.InvalidArgument, "interval must be positive")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	ctx := infoServer.Context()
+	for {
+		err = infoServer.Send(agent.info())
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// info will collect the current information about the server
+func (agent *Agent) info() *proto.InfoType {
 	var executableLStat, executableStat os.FileInfo
 	var executableLStatName, executableStatName string
 	var executableLStatSize, executableStatSize int64
@@ -259,6 +625,7 @@ func (agent *Agent) GetInfo(context.Context, *empty.Empty) (*proto.InfoType, err
 	if err != nil {
 		lastPause, _ = ptypes.TimestampProto(time.Unix(0, 0))
 	}
+	activeRPCs, totalRPCs := agent.rpcStats.snapshot()
 
 	return &proto.InfoType{
 		GOOS:         runtime.GOOS,
@@ -269,7 +636,7 @@ func (agent *Agent) GetInfo(context.Context, *empty.Empty) (*proto.InfoType, err
 		NumGoroutine: int32(runtime.NumGoroutine()),
 		Version:      runtime.Version(),
 		ProcessStats: &proto.ProcessStats{
-			Environ:    os.Environ(),
+			Environ:    agent.environ(),
 			Executable: executable,
 			ExecutableLStat: &proto.FileInfo{
 				Name:     executableLStatName,
@@ -338,8 +705,11 @@ func (agent *Agent) GetInfo(context.Context, *empty.Empty) (*proto.InfoType, err
 			NumGC:        memStats.NumGC,
 			NumForcedGC:  memStats.NumForcedGC,
 		},
-		MemProfileRate: int32(runtime.MemProfileRate),
-	}, nil
+		MemProfileRate:    int32(runtime.MemProfileRate),
+		NumUserGoroutines: int32(numUserGoroutines()),
+		ActiveRPCs:        activeRPCs,
+		TotalRPCs:         totalRPCs,
+	}
 }
 
 // BinaryDump function get the dump of the current binary
@@ -355,28 +725,166 @@ func (agent *Agent) BinaryDump(_ *empty.Empty, profileServer proto.ProfileServic
 		return
 	}
 	defer func() {
-		err = f.Close()
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
 	}()
 
-	_, err = bufio.NewReader(f).WriteTo(&grpcStreamWriter{profileServer})
-	return
+	// Copy in chunks, so a cancelled download stops reading the binary
+	ctx := profileServer.Context()
+	writer := agent.newStreamWriter(profileServer)
+	buf := make([]byte, agent.streamChunkSize())
+	for {
+		if err = ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		var n int
+		n, err = f.Read(buf)
+		if n > 0 {
+			if _, writeErr := writer.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err == io.EOF {
+			return writer.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// BinaryHash function will get the SHA-256 hash and the size of the current binary
+func (agent *Agent) BinaryHash(context.Context, *empty.Empty) (*proto.BinaryHashType, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.BinaryHashType{SHA256: hex.EncodeToString(hash.Sum(nil)), Size: size}, nil
 }
 
 // Set function will set the GRPC Profile Variable
 func (agent *Agent) Set(_ context.Context, inputType *proto.SetProfileInputType) (*proto.IntType, error) {
+	variableMutex.Lock()
+	defer variableMutex.Unlock()
+	return &proto.IntType{Value: agent.setVariable(inputType.Variable, inputType.Rate)}, nil
+}
+
+// SetMultiple function will set several GRPC Profile Variables in the given order while holding the variable lock, and
+// return their previous values. No variable is changed if one of them is unknown
+func (agent *Agent) SetMultiple(_ context.Context, inputType *proto.VariablesType) (*proto.VariablesType, error) {
+	for _, value := range inputType.Values {
+		if _, ok := proto.ProfileVariable_name[int32(value.Variable)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown variable %v", value.Variable)
+		}
+	}
+
+	variableMutex.Lock()
+	defer variableMutex.Unlock()
+	prev := &proto.VariablesType{Values: make([]*proto.VariableValue, len(inputType.Values))}
+	for i, value := range inputType.Values {
+		prev.Values[i] = &proto.VariableValue{Variable: value.Variable, Value: agent.setVariable(value.Variable, value.Value)}
+	}
+	return prev, nil
+}
+
+// setVariable will set the variable and return its previous value, -1 for unknown variables. The caller must hold
+// the variable lock
+func (agent *Agent) setVariable(variable proto.ProfileVariable, rate int32) int32 {
 	retValue := int32(-1)
-	switch inputType.Variable {
+	switch variable {
 	case proto.ProfileVariable_MemProfileRate:
 		retValue = int32(runtime.MemProfileRate)
-		runtime.MemProfileRate = int(inputType.Rate)
+		runtime.MemProfileRate = int(rate)
+	case proto.ProfileVariable_CPUProfileRate:
+		retValue = int32(cpuProfileRate)
+		runtime.SetCPUProfileRate(int(rate))
+		cpuProfileRate = int(rate)
+	case proto.ProfileVariable_MutexProfileFraction:
+		retValue = int32(runtime.SetMutexProfileFraction(int(rate)))
+	case proto.ProfileVariable_BlockProfileRate:
+		retValue = int32(blockProfileRate)
+		runtime.SetBlockProfileRate(int(rate))
+		blockProfileRate = int(rate)
+	case proto.ProfileVariable_GCPercent:
+		retValue = int32(debug.SetGCPercent(int(rate)))
+		agent.gcPercent = int(rate)
+	}
+	return retValue
+}
+
+// Get function will get the current value of the GRPC Profile Variable. CPUProfileRate and BlockProfileRate can not
+// be read from the runtime, so the last value set through an agent of the process (0 by default) is returned for them
+func (agent *Agent) Get(_ context.Context, inputType *proto.GetProfileInputType) (*proto.IntType, error) {
+	variableMutex.Lock()
+	defer variableMutex.Unlock()
+
+	var value int
+	switch inputType.Variable {
+	case proto.ProfileVariable_MemProfileRate:
+		value = runtime.MemProfileRate
 	case proto.ProfileVariable_CPUProfileRate:
-		runtime.SetCPUProfileRate(int(inputType.Rate))
+		value = cpuProfileRate
 	case proto.ProfileVariable_MutexProfileFraction:
-		retValue = int32(runtime.SetMutexProfileFraction(int(inputType.Rate)))
+		// A negative rate only reads the current fraction
+		value = runtime.SetMutexProfileFraction(-1)
 	case proto.ProfileVariable_BlockProfileRate:
-		runtime.SetBlockProfileRate(int(inputType.Rate))
+		value = blockProfileRate
+	case proto.ProfileVariable_GCPercent:
+		value = agent.readGCPercent()
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown variable %v", inputType.Variable)
+	}
+	return &proto.IntType{Value: int32(value)}, nil
+}
+
+// Reset function will restore the GRPC Profile Variable to its value when the agent was created and return the
+// previous value
+func (agent *Agent) Reset(ctx context.Context, inputType *proto.ResetProfileInputType) (*proto.IntType, error) {
+	value, ok := agent.initialValues[inputType.Variable]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown variable %v", inputType.Variable)
+	}
+	return agent.Set(ctx, &proto.SetProfileInputType{Variable: inputType.Variable, Rate: value})
+}
+
+// ResetAll function will restore every GRPC Profile Variable to its value when the agent was created and return the
+// previous values
+func (agent *Agent) ResetAll(ctx context.Context, _ *empty.Empty) (*proto.VariablesType, error) {
+	variables := make([]proto.ProfileVariable, 0, len(agent.initialValues))
+	for variable := range agent.initialValues {
+		variables = append(variables, variable)
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i] < variables[j] })
+
+	values := &proto.VariablesType{}
+	for _, variable := range variables {
+		prev, err := agent.Reset(ctx, &proto.ResetProfileInputType{Variable: variable})
+		if err != nil {
+			return nil, err
+		}
+		values.Values = append(values.Values, &proto.VariableValue{Variable: variable, Value: prev.Value})
+	}
+	return values, nil
+}
+
+// SetMaxProcs function will set GOMAXPROCS and return the previous value
+func (agent *Agent) SetMaxProcs(_ context.Context, n *proto.IntType) (*proto.IntType, error) {
+	if n.Value < 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "GOMAXPROCS must be at least 1, got %d", n.Value)
 	}
-	return &proto.IntType{Value: retValue}, nil
+	return &proto.IntType{Value: int32(runtime.GOMAXPROCS(int(n.Value)))}, nil
 }
 
 // GC function will run GC on remote agent
@@ -385,18 +893,79 @@ func (agent *Agent) GC(context.Context, *empty.Empty) (*empty.Empty, error) {
 	return &empty.Empty{}, nil
 }
 
+// FreeOSMemory function will force a GC on remote agent and return as much memory to the operating system as possible
+func (agent *Agent) FreeOSMemory(context.Context, *empty.Empty) (*empty.Empty, error) {
+	debug.FreeOSMemory()
+	return &empty.Empty{}, nil
+}
+
 // LookupProfile will run a profile for lookup pprof type
 func (agent *Agent) LookupProfile(inputType *proto.LookupProfileInputType, profileServer proto.ProfileService_LookupProfileServer) error {
-	prof := pprof.Lookup(lookupStr[inputType.ProfileType])
+	name, ok := lookupStr[inputType.ProfileType]
+	if !ok {
+		return statusWithReason(codes.NotFound, ReasonUnknownProfileType,
+			fmt.Sprintf("unknown profile type %v", inputType.ProfileType), nil)
+	}
+	prof := pprof.Lookup(name)
 	if prof == nil {
-		return nil
+		return status.Errorf(codes.NotFound, "unknown profile type %s", name)
+	}
+	if inputType.SampleType != "" && inputType.Debug != 0 {
+		return status.Error(codes.InvalidArgument, "sample type can only be selected for protobuf profiles")
+	}
+	if len(inputType.LabelFilters) != 0 && inputType.Debug != 0 {
+		return status.Error(codes.InvalidArgument, "label filters can only be used for protobuf profiles")
 	}
 
-	err := prof.WriteTo(&grpcStreamWriter{profileServer}, 0)
+	err := sendMeta(profileServer, name, 0)
 	if err != nil {
 		return err
 	}
-	return nil
+	if inputType.GCBefore && inputType.ProfileType == proto.LookupProfile_profileTypeHeap {
+		runtime.GC()
+	}
+
+	// Filtered profiles are decoded anyway, so their samples are counted. Other profiles are streamed as they are
+	// written and their number of records tells whether they are empty
+	writer, closeWriter := profileWriter(agent.newStreamWriter(profileServer), inputType.Compress)
+	noSamples := prof.Count() == 0
+	if inputType.SampleType != "" || len(inputType.LabelFilters) != 0 {
+		var buf bytes.Buffer
+		err = prof.WriteTo(&buf, 0)
+		if err == nil {
+			var samples int
+			samples, err = writeFiltered(&buf, writer, name, inputType.SampleType, inputType.LabelFilters)
+			noSamples = samples == 0
+		}
+	} else {
+		err = prof.WriteTo(writer, int(inputType.Debug))
+	}
+	if err != nil {
+		return err
+	}
+	if noSamples {
+		trailer, err := warningTrailer(ReasonProfileEmpty, "profile "+name+" has no samples")
+		if err != nil {
+			return err
+		}
+		profileServer.SetTrailer(trailer)
+	}
+	return closeWriter()
+}
+
+// sendMeta will send the chunk describing a profile ahead of its content
+func sendMeta(stream interface{ Send(*proto.FileChunk) error }, profileType string, duration time.Duration) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return stream.Send(&proto.FileChunk{Meta: &proto.ProfileMeta{
+		ProfileType: profileType,
+		Time:        ptypes.TimestampNow(),
+		Duration:    ptypes.DurationProto(duration),
+		Hostname:    hostname,
+		GoVersion:   runtime.Version(),
+	}})
 }
 
 func (agent *Agent) runNonLookup(ctx context.Context, startFunc func(io.Writer) error, stopFunc func(), duration time.Duration, writer io.Writer) error {
@@ -412,44 +981,188 @@ func (agent *Agent) runNonLookup(ctx context.Context, startFunc func(io.Writer)
 	return nil
 }
 
-// NonLookupProfile will run a profile for non lookup pprof type
-func (agent *Agent) NonLookupProfile(inputType *proto.NonLookupProfileInputType, profileServer proto.ProfileService_NonLookupProfileServer) error {
+// nonLookupFuncs will return the functions starting and stopping a non lookup profile type. They keep track of whether
+// a profile of the type is running, starting a second one fails with `codes.FailedPrecondition`
+func (agent *Agent) nonLookupFuncs(profileType proto.NonLookupProfile) (func(io.Writer) error, func(), error) {
 	var startFunc func(io.Writer) error
 	var stopFunc func()
-
-	switch inputType.ProfileType {
+	switch profileType {
 	case proto.NonLookupProfile_profileTypeCPU:
-		startFunc = pprof.StartCPUProfile
-		stopFunc = pprof.StopCPUProfile
+		backend := agent.cpuProfiler()
+		startFunc, stopFunc = backend.Start, backend.Stop
 	case proto.NonLookupProfile_profileTypeTrace:
-		startFunc = trace.Start
-		stopFunc = trace.Stop
+		startFunc, stopFunc = trace.Start, trace.Stop
 	default:
-		return errors.New("unknown profile type")
+		return nil, nil, statusWithReason(codes.InvalidArgument, ReasonUnknownProfileType,
+			fmt.Sprintf("unknown profile type %v", profileType), nil)
+	}
+
+	start := func(writer io.Writer) error {
+		agent.runningMutex.Lock()
+		defer agent.runningMutex.Unlock()
+		if agent.running[profileType] {
+			return status.Errorf(codes.FailedPrecondition, "a %s profile is already running", nonLookupStr[profileType])
+		}
+		err := startFunc(writer)
+		if err != nil {
+			return err
+		}
+		if agent.running == nil {
+			agent.running = make(map[proto.NonLookupProfile]bool)
+		}
+		agent.running[profileType] = true
+		return nil
+	}
+	stop := func() {
+		stopFunc()
+		agent.setRunning(profileType, false)
+	}
+	return start, stop, nil
+}
+
+func (agent *Agent) setRunning(profileType proto.NonLookupProfile, running bool) {
+	agent.runningMutex.Lock()
+	defer agent.runningMutex.Unlock()
+	if agent.running == nil {
+		agent.running = make(map[proto.NonLookupProfile]bool)
+	}
+	agent.running[profileType] = running
+}
+
+// NonLookupProfile will run a profile for non lookup pprof type
+func (agent *Agent) NonLookupProfile(inputType *proto.NonLookupProfileInputType, profileServer proto.ProfileService_NonLookupProfileServer) error {
+	startFunc, stopFunc, err := agent.nonLookupFuncs(inputType.ProfileType)
+	if err != nil {
+		return err
 	}
 
 	dur, err := ptypes.Duration(inputType.Duration)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if dur <= 0 {
+		return status.Errorf(codes.InvalidArgument, "duration must be positive, got %v", dur)
+	}
+	dur, err = agent.profileDuration(dur)
 	if err != nil {
 		return err
 	}
+	if len(inputType.LabelFilters) != 0 && inputType.ProfileType == proto.NonLookupProfile_profileTypeTrace {
+		return status.Error(codes.InvalidArgument, "label filters can not be used for traces")
+	}
 
-	writer := grpcStreamWriter{profileServer}
-	err = agent.runNonLookup(profileServer.Context(), startFunc, stopFunc, dur, &writer)
+	release, err := agent.acquireProfile(profileServer.Context(), inputType.ProfileType)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer release()
+
+	err = sendMeta(profileServer, nonLookupStr[inputType.ProfileType], dur)
+	if err != nil {
+		return err
+	}
+
+	writer, closeWriter := profileWriter(agent.newStreamWriter(profileServer), inputType.Compress)
+	if len(inputType.LabelFilters) != 0 {
+		var buf bytes.Buffer
+		err = agent.runNonLookup(profileServer.Context(), startFunc, stopFunc, dur, &buf)
+		if err == nil {
+			_, err = writeFiltered(&buf, writer, nonLookupStr[inputType.ProfileType], "", inputType.LabelFilters)
+		}
+	} else {
+		err = agent.runNonLookup(profileServer.Context(), startFunc, stopFunc, dur, writer)
+	}
+	if err != nil {
+		return err
+	}
+	return closeWriter()
+}
+
+// ContinuousProfile will run back to back profiles of a non lookup pprof type, each one lasting the requested
+// interval, and stream them until the client cancels. Every profile is sent as one or more `proto.ProfileFrame`s
+// sharing a sequence number and carrying the total length of the profile. Like the other profile streams, every
+// profile is gzip compressed if requested and the stream is aborted by a profile larger than `WithMaxProfileSize()`
+func (agent *Agent) ContinuousProfile(inputType *proto.ContinuousProfileInputType, profileServer proto.ProfileService_ContinuousProfileServer) error {
+	startFunc, stopFunc, err := agent.nonLookupFuncs(inputType.ProfileType)
+	if err != nil {
+		return err
+	}
+
+	interval, err := ptypes.Duration(inputType.Interval)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if interval <= 0 {
+		return status.Errorf(codes.InvalidArgument, "interval must be positive, got %v", interval)
+	}
+	interval, err = agent.profileDuration(interval)
+	if err != nil {
+		return err
+	}
+
+	ctx := profileServer.Context()
+	release, err := agent.acquireProfile(ctx, inputType.ProfileType)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var buf, frame bytes.Buffer
+	for sequence := uint32(0); ; sequence++ {
+		buf.Reset()
+		err = agent.runNonLookup(ctx, startFunc, stopFunc, interval, &buf)
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			// The last profile is incomplete
+			return status.FromContextError(err).Err()
+		}
+
+		content := &buf
+		if inputType.Compress {
+			frame.Reset()
+			gz := gzip.NewWriter(&frame)
+			if _, err = buf.WriteTo(gz); err != nil {
+				return err
+			}
+			if err = gz.Close(); err != nil {
+				return err
+			}
+			content = &frame
+		}
+		length := int64(content.Len())
+		if agent.maxProfileSize > 0 && length > agent.maxProfileSize {
+			return profileTooLarge(agent.maxProfileSize)
+		}
+		for sent := false; !sent || content.Len() > 0; sent = true {
+			err = profileServer.Send(&proto.ProfileFrame{
+				Sequence: sequence,
+				Length:   length,
+				Content:  content.Next(agent.streamChunkSize()),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
 }
 
-// StopNonLookupProfile will stop non lookup profile type (if running)
+// StopNonLookupProfile will stop a running non lookup profile type. It returns `codes.FailedPrecondition` if no
+// profile of the type was started through the agent
 func (agent *Agent) StopNonLookupProfile(_ context.Context, profileType *proto.NonLookupProfileType) (*empty.Empty, error) {
-	switch profileType.Profile {
-	case proto.NonLookupProfile_profileTypeCPU:
-		pprof.StopCPUProfile()
-	case proto.NonLookupProfile_profileTypeTrace:
-		trace.Stop()
-	default:
-		return &empty.Empty{}, errors.New("unknown profile type")
+	_, stopFunc, err := agent.nonLookupFuncs(profileType.Profile)
+	if err != nil {
+		return &empty.Empty{}, err
 	}
+
+	agent.runningMutex.Lock()
+	running := agent.running[profileType.Profile]
+	agent.runningMutex.Unlock()
+	if !running {
+		return nil, status.Errorf(codes.FailedPrecondition, "no %s profile is running", nonLookupStr[profileType.Profile])
+	}
+
+	stopFunc()
 	return &empty.Empty{}, nil
 }
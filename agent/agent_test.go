@@ -0,0 +1,539 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"runtime/debug"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startTestAgent will start agent on a free local port and return a client connected to it. Both are stopped when the
+// test ends
+func startTestAgent(t *testing.T, agent *Agent) (proto.ProfileServiceClient, *grpc.ClientConn) {
+	t.Helper()
+	addr, _, err := agent.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.DialContext(context.Background(), addr.String(), grpc.WithInsecure())
+	if err != nil {
+		agent.Stop()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		agent.Stop()
+	})
+	return proto.NewProfileServiceClient(conn), conn
+}
+
+// newTestAgent will create an agent with options and start it with `startTestAgent()`
+func newTestAgent(t *testing.T, options ...*ServerOption) (*Agent, proto.ProfileServiceClient, *grpc.ClientConn) {
+	t.Helper()
+	agent, err := NewAgent(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, conn := startTestAgent(t, agent)
+	return agent, client, conn
+}
+
+// chunkStream is the client side of the RPCs streaming `proto.FileChunk`s
+type chunkStream interface {
+	Recv() (*proto.FileChunk, error)
+}
+
+// drain will receive the chunks of stream until it ends, and return nil if it ends without an error
+func drain(stream chunkStream) error {
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestSetGet(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		variable proto.ProfileVariable
+		rate     int32
+	}{
+		{proto.ProfileVariable_MemProfileRate, 1024},
+		{proto.ProfileVariable_MutexProfileFraction, 5},
+		{proto.ProfileVariable_BlockProfileRate, 1000},
+		{proto.ProfileVariable_GCPercent, 150},
+	} {
+		previous, err := client.Set(ctx, &proto.SetProfileInputType{Variable: tc.variable, Rate: tc.rate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := client.Get(ctx, &proto.GetProfileInputType{Variable: tc.variable})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value.Value != tc.rate {
+			t.Errorf("get %v after set: got %d, want %d", tc.variable, value.Value, tc.rate)
+		}
+		_, err = client.Set(ctx, &proto.SetProfileInputType{Variable: tc.variable, Rate: previous.Value})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestResetBlockProfileRate(t *testing.T) {
+	ctx := context.Background()
+	first, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	initial, err := first.Get(ctx, &proto.GetProfileInputType{Variable: proto.ProfileVariable_BlockProfileRate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Reset(ctx, &proto.ResetProfileInputType{Variable: proto.ProfileVariable_BlockProfileRate})
+	if _, err = first.Set(ctx, &proto.SetProfileInputType{Variable: proto.ProfileVariable_BlockProfileRate, Rate: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second agent records the rate set by the first one as its startup value
+	second, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = second.Set(ctx, &proto.SetProfileInputType{Variable: proto.ProfileVariable_BlockProfileRate, Rate: 5}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name       string
+		agent      *Agent
+		prev, want int32
+	}{
+		{"second", second, 5, 100},
+		{"first", first, 100, initial.Value},
+	} {
+		prev, err := tc.agent.Reset(ctx, &proto.ResetProfileInputType{Variable: proto.ProfileVariable_BlockProfileRate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := tc.agent.Get(ctx, &proto.GetProfileInputType{Variable: proto.ProfileVariable_BlockProfileRate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prev.Value != tc.prev || value.Value != tc.want {
+			t.Errorf("reset block rate of the %s agent: got %d to %d, want %d to %d", tc.name, prev.Value, value.Value, tc.prev, tc.want)
+		}
+	}
+}
+
+func TestGetGCPercentReadOnly(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := debug.SetGCPercent(123)
+	defer debug.SetGCPercent(previous)
+
+	value, err := agent.Get(context.Background(), &proto.GetProfileInputType{Variable: proto.ProfileVariable_GCPercent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Value != 123 {
+		t.Errorf("get GC percent: got %d, want 123", value.Value)
+	}
+	if current := debug.SetGCPercent(previous); current != 123 {
+		t.Errorf("GC percent after get: got %d, want 123", current)
+	}
+}
+
+func TestFreeOSMemory(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+
+	_, err := client.FreeOSMemory(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// continuousStream is a `proto.ProfileService_ContinuousProfileServer` discarding the frames sent on it
+type continuousStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *continuousStream) Context() context.Context       { return stream.ctx }
+func (stream *continuousStream) Send(*proto.ProfileFrame) error { return nil }
+
+func TestContinuousProfileContextError(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = agent.ContinuousProfile(&proto.ContinuousProfileInputType{
+		ProfileType: proto.NonLookupProfile_profileTypeCPU,
+		Interval:    ptypes.DurationProto(time.Second),
+	}, &continuousStream{ctx: ctx})
+	if code := status.Code(err); code != codes.DeadlineExceeded {
+		t.Errorf("continuous profile past the deadline: got %v (%v), want %v", code, err, codes.DeadlineExceeded)
+	}
+}
+
+func TestNonLookupProfileClientCancel(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	stream, err := client.NonLookupProfile(ctx, &proto.NonLookupProfileInputType{
+		ProfileType: proto.NonLookupProfile_profileTypeCPU,
+		Duration:    ptypes.DurationProto(10 * time.Second),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(200*time.Millisecond, cancel)
+	if err = drain(stream); status.Code(err) != codes.Canceled {
+		t.Errorf("cancelled CPU profile: got %v, want %v", err, codes.Canceled)
+	}
+
+	// The agent stops the CPU profiler, so it can be started again long before the 10s are over
+	for {
+		err = pprof.StartCPUProfile(ioutil.Discard)
+		if err == nil {
+			pprof.StopCPUProfile()
+			break
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Fatalf("CPU profiler still running %v after the cancel: %v", time.Since(start), err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNonLookupProfileZeroDuration(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+
+	stream, err := client.NonLookupProfile(context.Background(), &proto.NonLookupProfileInputType{
+		ProfileType: proto.NonLookupProfile_profileTypeCPU,
+		Duration:    ptypes.DurationProto(0),
+	})
+	if err == nil {
+		err = drain(stream)
+	}
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("0s CPU profile: got %v (%v), want %v", code, err, codes.InvalidArgument)
+	}
+}
+
+func TestLookupProfileUnknownType(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+
+	stream, err := client.LookupProfile(context.Background(), &proto.LookupProfileInputType{ProfileType: proto.LookupProfile(99)})
+	if err == nil {
+		err = drain(stream)
+	}
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("out of range profile type: got %v (%v), want %v", code, err, codes.NotFound)
+	}
+}
+
+func TestStopNonLookupProfileNotRunning(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+
+	_, err := client.StopNonLookupProfile(context.Background(), &proto.NonLookupProfileType{Profile: proto.NonLookupProfile_profileTypeCPU})
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("stop without a running profile: got %v (%v), want %v", code, err, codes.FailedPrecondition)
+	}
+}
+
+// cancellingStream is a `proto.ProfileService_BinaryDumpServer` cancelling its context once a chunk is sent
+type cancellingStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	sent   int
+}
+
+func (stream *cancellingStream) Context() context.Context { return stream.ctx }
+
+func (stream *cancellingStream) Send(*proto.FileChunk) error {
+	stream.sent++
+	stream.cancel()
+	return nil
+}
+
+func TestBinaryDumpCancel(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &cancellingStream{ctx: ctx, cancel: cancel}
+
+	done := make(chan error, 1)
+	go func() { done <- agent.BinaryDump(&empty.Empty{}, stream) }()
+	select {
+	case err = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("binary dump did not return after the cancellation")
+	}
+	if code := status.Code(err); code != codes.Canceled {
+		t.Errorf("cancelled binary dump: got %v (%v), want %v", code, err, codes.Canceled)
+	}
+	if stream.sent != 1 {
+		t.Errorf("chunks sent after the cancellation: got %d, want 0", stream.sent-1)
+	}
+}
+
+func TestGracefulStopBinaryDump(t *testing.T) {
+	agent, client, _ := newTestAgent(t)
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := client.BinaryDump(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		agent.GracefulStop(0)
+		close(stopped)
+	}()
+
+	received := append([]byte(nil), chunk.Content...)
+	for {
+		chunk, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("binary dump during a graceful stop: %v", err)
+		}
+		received = append(received, chunk.Content...)
+	}
+	<-stopped
+	if !bytes.Equal(received, binary) {
+		t.Errorf("binary dump during a graceful stop: got %d bytes, want the %d bytes of the binary", len(received), len(binary))
+	}
+}
+
+func TestStartServeError(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, errs, err := agent.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Stop()
+
+	// Serve fails once the listener is closed without stopping the agent
+	err = agent.listen.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err = <-errs:
+		if err == nil {
+			t.Error("serving on a closed listener: got no error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve error was not delivered")
+	}
+}
+
+// recordingStream records the content of the chunks sent on it
+type recordingStream struct {
+	chunks [][]byte
+}
+
+func (stream *recordingStream) Send(chunk *proto.FileChunk) error {
+	stream.chunks = append(stream.chunks, chunk.Content)
+	return nil
+}
+
+func TestServerWithChunkSize(t *testing.T) {
+	var content bytes.Buffer
+	err := pprof.Lookup("heap").WriteTo(&content, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunkSize := range []int{16, 1 << 20} {
+		agent, err := NewAgent(ServerWithChunkSize(chunkSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream := &recordingStream{}
+		writer := agent.newStreamWriter(stream)
+		// Write in pieces which do not line up with the chunks
+		for remaining := content.Bytes(); len(remaining) > 0; {
+			n := len(remaining)
+			if n > 1000 {
+				n = 1000
+			}
+			_, err = writer.Write(remaining[:n])
+			if err != nil {
+				t.Fatal(err)
+			}
+			remaining = remaining[n:]
+		}
+		err = writer.Flush()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var reassembled []byte
+		for _, chunk := range stream.chunks {
+			if len(chunk) > chunkSize {
+				t.Errorf("chunk size %d: got a chunk of %d bytes", chunkSize, len(chunk))
+			}
+			reassembled = append(reassembled, chunk...)
+		}
+		if !bytes.Equal(reassembled, content.Bytes()) {
+			t.Errorf("chunk size %d: reassembled %d bytes differ from the %d bytes of the profile", chunkSize, len(reassembled), content.Len())
+		}
+	}
+}
+
+func TestMaxProfileDuration(t *testing.T) {
+	_, client, _ := newTestAgent(t, ServerWithMaxProfileDuration(5*time.Minute, false))
+
+	stream, err := client.NonLookupProfile(context.Background(), &proto.NonLookupProfileInputType{
+		ProfileType: proto.NonLookupProfile_profileTypeCPU,
+		Duration:    ptypes.DurationProto(2 * time.Hour),
+	})
+	if err == nil {
+		err = drain(stream)
+	}
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("2h CPU profile with a 5m cap: got %v (%v), want %v", code, err, codes.InvalidArgument)
+	}
+}
+
+func TestAddr(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.Addr() != nil {
+		t.Errorf("address of an agent which is not started: got %v, want nil", agent.Addr())
+	}
+	_, _, err = agent.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Stop()
+	if agent.Addr().Port == 0 {
+		t.Fatal("agent started on port 0 reports port 0")
+	}
+
+	conn, err := grpc.DialContext(context.Background(), agent.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_, err = proto.NewProfileServiceClient(conn).Ping(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Errorf("ping on the reported address: %v", err)
+	}
+}
+
+func TestStartContextCancel(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	addr, errs, err := agent.StartContext(ctx, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	select {
+	case err = <-errs:
+		if err != nil {
+			t.Errorf("serve error after the cancellation: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("agent was not stopped after the cancellation")
+	}
+	conn, err := net.Dial("tcp", addr.String())
+	if err == nil {
+		conn.Close()
+		t.Error("listener is still open after the cancellation")
+	}
+}
+
+func TestGetInfoExecutableStat(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+	info, err := client.GetInfo(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		got  *proto.FileInfo
+		stat func(string) (os.FileInfo, error)
+	}{
+		"lstat": {info.ProcessStats.ExecutableLStat, os.Lstat},
+		"stat":  {info.ProcessStats.ExecutableStat, os.Stat},
+	} {
+		want, err := tc.stat(executable)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.got == nil || tc.got.ModeTime == nil {
+			t.Fatalf("%s of the executable has no ModTime: %v", name, tc.got)
+		}
+		modTime, err := ptypes.Timestamp(tc.got.ModeTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if modTime.Unix() == 0 || !modTime.Equal(want.ModTime()) {
+			t.Errorf("%s ModTime of the executable: got %v, want %v", name, modTime, want.ModTime())
+		}
+		if tc.got.Name != want.Name() || tc.got.Size != want.Size() || os.FileMode(tc.got.Mode) != want.Mode() {
+			t.Errorf("%s of the executable: got %v, want %s of %d bytes with mode %v", name, tc.got, want.Name(), want.Size(), want.Mode())
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"errors"
+	"io"
+	"runtime/pprof"
+)
+
+// CPUBackend will collect CPU profiles for the GRPC Profile Agent. A backend must write a standard pprof profile, so
+// clients do not need to know which one collected it
+type CPUBackend interface {
+	// Available reports whether the backend can be used on the current platform
+	Available() bool
+
+	// Start starts collecting a CPU profile into writer
+	Start(writer io.Writer) error
+
+	// Stop stops the running CPU profile and flushes it
+	Stop()
+}
+
+type pprofCPUBackend struct{}
+
+func (pprofCPUBackend) Available() bool {
+	return true
+}
+
+func (pprofCPUBackend) Start(writer io.Writer) error {
+	return pprof.StartCPUProfile(writer)
+}
+
+func (pprofCPUBackend) Stop() {
+	pprof.StopCPUProfile()
+}
+
+// PprofCPUBackend is the default CPUBackend which uses `runtime/pprof` (SIGPROF based sampling)
+var PprofCPUBackend CPUBackend = pprofCPUBackend{}
+
+// WithCPUBackend function will create a GRPC Profile Agent option to collect CPU profiles using backend, e.g. a
+// hardware performance counter based one. If the backend is not available on the current platform the agent falls
+// back to PprofCPUBackend
+func WithCPUBackend(backend CPUBackend) *ServerOption {
+	if backend == nil {
+		return &ServerOption{error: errors.New("CPU backend can not be nil")}
+	}
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.cpuBackend = backend
+	}}
+}
+
+// cpuProfiler will return the CPUBackend to use for CPU profiles
+func (agent *Agent) cpuProfiler() CPUBackend {
+	if agent.cpuBackend != nil && agent.cpuBackend.Available() {
+		return agent.cpuBackend
+	}
+	return PprofCPUBackend
+}
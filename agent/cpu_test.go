@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/pprof/profile"
+)
+
+// fakeCPUBackend is a CPUBackend writing a profile of one sample, if it is available
+type fakeCPUBackend struct {
+	available bool
+	started   int
+	stopped   int
+	writer    io.Writer
+	mutex     sync.Mutex
+}
+
+func (backend *fakeCPUBackend) Available() bool {
+	return backend.available
+}
+
+func (backend *fakeCPUBackend) Start(writer io.Writer) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	backend.started++
+	backend.writer = writer
+	return nil
+}
+
+func (backend *fakeCPUBackend) Stop() {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	backend.stopped++
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "cycles", Unit: "count"}},
+		Sample:     []*profile.Sample{{Value: []int64{42}}},
+	}
+	_ = p.Write(backend.writer)
+}
+
+func TestWithCPUBackend(t *testing.T) {
+	if _, err := NewAgent(WithCPUBackend(nil)); err == nil {
+		t.Error("nil CPU backend: got no error")
+	}
+
+	for _, available := range []bool{false, true} {
+		backend := &fakeCPUBackend{available: available}
+		_, client, _ := newTestAgent(t, WithCPUBackend(backend))
+		stream, err := client.NonLookupProfile(context.Background(), &proto.NonLookupProfileInputType{
+			ProfileType: proto.NonLookupProfile_profileTypeCPU,
+			Duration:    ptypes.DurationProto(50 * time.Millisecond),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		for {
+			var chunk *proto.FileChunk
+			chunk, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			buf.Write(chunk.Content)
+		}
+		if err != io.EOF {
+			t.Fatal(err)
+		}
+		p, err := profile.Parse(&buf)
+		if err != nil {
+			t.Fatalf("CPU profile with an available backend %t: %v", available, err)
+		}
+
+		backend.mutex.Lock()
+		started, stopped := backend.started, backend.stopped
+		backend.mutex.Unlock()
+		if !available {
+			// The agent falls back to runtime/pprof
+			if started != 0 || p.SampleType[0].Type == "cycles" {
+				t.Errorf("unavailable backend: started %d times, sample types %v", started, p.SampleType)
+			}
+			continue
+		}
+		if started != 1 || stopped != 1 {
+			t.Errorf("available backend: started %d and stopped %d times, want once", started, stopped)
+		}
+		if len(p.Sample) != 1 || p.Sample[0].Value[0] != 42 {
+			t.Errorf("profile of the available backend: got %v, want its sample", p.Sample)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package agent
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DefaultEnvironRedaction is the pattern of environment variable names redacted from `GetInfo` by default
+const DefaultEnvironRedaction = `(?i)(SECRET|PASSWORD|PASSWD|TOKEN|CREDENTIAL|PRIVATE|API_?KEY|ACCESS_?KEY|AUTH)`
+
+const redactedValue = "***"
+
+var defaultEnvironRedaction = regexp.MustCompile(DefaultEnvironRedaction)
+
+// WithEnvironRedaction function will create a GRPC Profile Agent option to mask the value of every environment
+// variable whose name matches pattern as "***" in `GetInfo`. An empty pattern disables the redaction. By default
+// `DefaultEnvironRedaction` is used
+func WithEnvironRedaction(pattern string) *ServerOption {
+	if pattern == "" {
+		return &ServerOption{apply: func(agent *Agent) { agent.environRedaction = nil }}
+	}
+	expression, err := regexp.Compile(pattern)
+	if err != nil {
+		return &ServerOption{error: err}
+	}
+	return &ServerOption{apply: func(agent *Agent) { agent.environRedaction = expression }}
+}
+
+// WithoutEnviron function will create a GRPC Profile Agent option to omit the environment from `GetInfo` entirely
+func WithoutEnviron() *ServerOption {
+	return &ServerOption{apply: func(agent *Agent) { agent.omitEnviron = true }}
+}
+
+// environ will return the process environment after applying the agent's omission and redaction settings
+func (agent *Agent) environ() []string {
+	if agent.omitEnviron {
+		return nil
+	}
+	environ := os.Environ()
+	if agent.environRedaction == nil {
+		return environ
+	}
+	for i, env := range environ {
+		kv := strings.SplitN(env, "=", 2)
+		if agent.environRedaction.MatchString(kv[0]) {
+			environ[i] = kv[0] + "=" + redactedValue
+		}
+	}
+	return environ
+}
@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+func TestEnvironRedaction(t *testing.T) {
+	for name, value := range map[string]string{"GRPC_PROFILE_TEST_SECRET": "hunter2", "GRPC_PROFILE_TEST_PLAIN": "visible"} {
+		if err := os.Setenv(name, value); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Unsetenv(name)
+	}
+
+	for _, test := range []struct {
+		name    string
+		options []*ServerOption
+		want    map[string]string
+	}{
+		{"default", nil, map[string]string{"GRPC_PROFILE_TEST_SECRET": "***", "GRPC_PROFILE_TEST_PLAIN": "visible"}},
+		{"pattern", []*ServerOption{WithEnvironRedaction("PLAIN$")}, map[string]string{"GRPC_PROFILE_TEST_SECRET": "hunter2", "GRPC_PROFILE_TEST_PLAIN": "***"}},
+		{"disabled", []*ServerOption{WithEnvironRedaction("")}, map[string]string{"GRPC_PROFILE_TEST_SECRET": "hunter2", "GRPC_PROFILE_TEST_PLAIN": "visible"}},
+		{"omitted", []*ServerOption{WithoutEnviron()}, map[string]string{}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, client, _ := newTestAgent(t, test.options...)
+			info, err := client.GetInfo(context.Background(), &empty.Empty{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for _, env := range info.ProcessStats.Environ {
+				for name := range test.want {
+					if strings.HasPrefix(env, name+"=") {
+						got[name] = strings.TrimPrefix(env, name+"=")
+					}
+				}
+			}
+			if test.name == "omitted" && len(info.ProcessStats.Environ) != 0 {
+				t.Errorf("environ: got %d variables, want none", len(info.ProcessStats.Environ))
+			}
+			for name, want := range test.want {
+				if got[name] != want {
+					t.Errorf("%s: got %q, want %q", name, got[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package agent
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// errorDomain is the domain of the `errdetails.ErrorInfo` details attached to errors returned by the agent
+	errorDomain = "grpc-profile"
+
+	// ReasonProfilingDegraded is the `errdetails.ErrorInfo` reason reported by the health check when the agent is
+	// reachable but at least one profiler is broken
+	ReasonProfilingDegraded = "PROFILING_DEGRADED"
+
+	// ReasonProfileTooLarge is the `errdetails.ErrorInfo` reason reported when a profile stream is aborted because it
+	// exceeds the size limit set with `WithMaxProfileSize()`
+	ReasonProfileTooLarge = "PROFILE_TOO_LARGE"
+
+	// ReasonUnknownProfileType is the `errdetails.ErrorInfo` reason reported when the requested profile type is not one
+	// of the types known by the agent
+	ReasonUnknownProfileType = "UNKNOWN_PROFILE_TYPE"
+
+	// ReasonProfileEmpty is the `errdetails.ErrorInfo` reason of the warning sent when a lookup profile has no samples
+	ReasonProfileEmpty = "PROFILE_EMPTY"
+)
+
+// statusWithReason will create a GRPC status error carrying an `errdetails.ErrorInfo` detail with reason
+func statusWithReason(code codes.Code, reason, message string, metadata map[string]string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
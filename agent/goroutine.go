@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+)
+
+// numUserGoroutines will return the number of goroutines excluding the ones started by the Go runtime. This is an
+// approximation: the goroutine profile is parsed and every goroutine whose outermost frame is a runtime function
+// (GC workers, finalizers, timers etc.) other than
+// `runtime.main` is subtracted
+func numUserGoroutines() int {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return 0
+	}
+
+	var total, count int
+	var entry string
+	flush := func() {
+		if count > 0 && (entry == "runtime.main" || !strings.HasPrefix(entry, "runtime.")) {
+			total += count
+		}
+		count, entry = 0, ""
+	}
+
+	// Each record looks like "<count> @ <pc>..." followed by one "#\t<pc>\t<function>+<offset>\t<file>:<line>"
+	// line per frame, innermost first
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "#\t"):
+			if fields := strings.Split(line, "\t"); len(fields) >= 3 {
+				entry = fields[2]
+			}
+		case strings.Contains(line, " @ "):
+			flush()
+			count, _ = strconv.Atoi(strings.Fields(line)[0])
+		case line == "":
+			flush()
+		}
+	}
+	flush()
+	return total
+}
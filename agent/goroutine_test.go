@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+func TestNumUserGoroutines(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+	ctx := context.Background()
+
+	before, err := client.GetInfo(ctx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const spawned = 20
+	stop := make(chan struct{})
+	defer close(stop)
+	for i := 0; i < spawned; i++ {
+		go func() {
+			<-stop
+		}()
+	}
+
+	after, err := client.GetInfo(ctx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.NumUserGoroutines > after.NumGoroutine {
+		t.Errorf("user goroutines: got %d, want at most the %d goroutines", after.NumUserGoroutines, after.NumGoroutine)
+	}
+	// The connection may start or end a few goroutines of its own between the calls
+	if delta := after.NumUserGoroutines - before.NumUserGoroutines; delta < spawned-2 || delta > spawned+5 {
+		t.Errorf("user goroutines after spawning %d: got %d more, want about %d", spawned, delta, spawned)
+	}
+}
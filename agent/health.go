@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthSelfTestInterval is how long the result of the profiler self test is reused by health checks. Collecting
+// every lookup profile stops the world for the goroutine profile, so frequent probes must not run it every time
+const healthSelfTestInterval = time.Minute
+
+// profiler is the part of `*pprof.Profile` used by the self test
+type profiler interface {
+	WriteTo(w io.Writer, debug int) error
+}
+
+// healthServer will report the health of the GRPC Profile Agent using the standard GRPC health checking protocol.
+// A working agent is reported as SERVING. If any profiler fails its self test the check returns
+// `codes.Unavailable` with an `errdetails.ErrorInfo` detail carrying ReasonProfilingDegraded, so load balancers can
+// route away from it while a down agent still looks different (no response at all)
+type healthServer struct {
+	// lookup returns the profiler of a lookup profile, it is replaced in tests to make a profiler fail
+	lookup func(name string) profiler
+
+	mutex    sync.Mutex
+	tested   time.Time
+	testErr  error
+	interval time.Duration
+}
+
+func newHealthServer() *healthServer {
+	return &healthServer{
+		lookup: func(name string) profiler {
+			if prof := pprof.Lookup(name); prof != nil {
+				return prof
+			}
+			return nil
+		},
+		interval: healthSelfTestInterval,
+	}
+}
+
+// selfTest will return the result of the last profiler self test, running it again if it is older than the interval
+func (health *healthServer) selfTest() error {
+	health.mutex.Lock()
+	defer health.mutex.Unlock()
+	if !health.tested.IsZero() && time.Since(health.tested) < health.interval {
+		return health.testErr
+	}
+	health.testErr = nil
+	for _, name := range lookupStr {
+		if err := health.selfTestProfile(name); err != nil {
+			health.testErr = err
+			break
+		}
+	}
+	health.tested = time.Now()
+	return health.testErr
+}
+
+func (health *healthServer) selfTestProfile(name string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s profile panicked: %v", name, r)
+		}
+	}()
+	prof := health.lookup(name)
+	if prof == nil {
+		return fmt.Errorf("%s profile not found", name)
+	}
+	if err = prof.WriteTo(ioutil.Discard, 0); err != nil {
+		return fmt.Errorf("%s profile failed: %v", name, err)
+	}
+	return nil
+}
+
+// Check function will report the health of the agent from the profiler self test, which runs at most once per
+// interval
+func (health *healthServer) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if err := health.selfTest(); err != nil {
+		return nil, statusWithReason(codes.Unavailable, ReasonProfilingDegraded, "profiling degraded: "+err.Error(),
+			map[string]string{"error": err.Error()})
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// Watch function is not supported by the GRPC Profile Agent health service
+func (*healthServer) Watch(*healthpb.HealthCheckRequest, healthpb.Health_WatchServer) error {
+	return status.Error(codes.Unimplemented, "watch is not supported, use check")
+}
@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type failingProfiler struct{}
+
+func (failingProfiler) WriteTo(io.Writer, int) error {
+	return errors.New("broken")
+}
+
+func TestHealthDegraded(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookup := agent.health.lookup
+	agent.health.lookup = func(name string) profiler {
+		if name == "block" {
+			return failingProfiler{}
+		}
+		return lookup(name)
+	}
+	client, conn := startTestAgent(t, agent)
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("health check: got %v, want %v", err, codes.Unavailable)
+	}
+	var reason string
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			reason = info.Reason
+		}
+	}
+	if reason != ReasonProfilingDegraded {
+		t.Errorf("health check reason: got %q, want %q", reason, ReasonProfilingDegraded)
+	}
+
+	_, err = client.Ping(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Errorf("ping of a degraded agent: %v", err)
+	}
+}
+
+func TestHealthSelfTestCached(t *testing.T) {
+	health := newHealthServer()
+	lookup := health.lookup
+	runs := 0
+	health.lookup = func(name string) profiler {
+		runs++
+		return lookup(name)
+	}
+
+	for i := 0; i < 3; i++ {
+		resp, err := health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("health check: got %v, want %v", resp.Status, healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+	if runs != len(lookupStr) {
+		t.Errorf("profilers collected by 3 checks: got %d, want %d", runs, len(lookupStr))
+	}
+}
@@ -0,0 +1,145 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpEndpoint is an optional HTTP listener served next to the GRPC server
+type httpEndpoint struct {
+	address string
+	handler func(agent *Agent) http.Handler
+	listen  net.Listener
+	server  *http.Server
+}
+
+// WithHTTPPprof function will create a GRPC Profile Agent option to also serve the standard `net/http/pprof` handlers
+// under /debug/pprof/ on address, so tools like `go tool pprof http://address/debug/pprof/heap` work without the GRPC
+// client. The variables changed through the agent apply to these profiles as well. The handlers are served on their
+// own mux, but importing `net/http/pprof` also registers them on `http.DefaultServeMux`.
+//
+// The listener serves plain HTTP, the TLS Auth type options do not apply to it, and neither do the rate, concurrency
+// and duration limits of the agent. Requests are checked against `ServerWithAllowedPeers` and `ServerAuthTypeToken`
+// if they are set. As /debug/pprof/cmdline exposes the command line of the process, bind address to localhost, e.g.
+// "127.0.0.1:6060", unless the network is trusted
+func WithHTTPPprof(address string) *ServerOption {
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.pprofHTTP = &httpEndpoint{address: address, handler: pprofHandler}
+	}}
+}
+
+func pprofHandler(*Agent) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// HTTPAddr function will return the address of the `net/http/pprof` listener, or nil if it is not enabled
+func (agent *Agent) HTTPAddr() *net.TCPAddr {
+	return agent.pprofHTTP.addr()
+}
+
+// httpEndpoints will return the enabled HTTP listeners
+func (agent *Agent) httpEndpoints() []*httpEndpoint {
+	var endpoints []*httpEndpoint
+	for _, endpoint := range []*httpEndpoint{agent.pprofHTTP, agent.metricsHTTP} {
+		if endpoint != nil {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// startHTTP will start the enabled HTTP listeners. If one of them fails the already started ones are stopped
+func (agent *Agent) startHTTP() error {
+	endpoints := agent.httpEndpoints()
+	for i, endpoint := range endpoints {
+		err := endpoint.start(agent.checkHTTP(endpoint.handler(agent)))
+		if err != nil {
+			for _, started := range endpoints[:i] {
+				started.stop(false, 0)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkHTTP will wrap handler to reject the requests failing one of the HTTP checks of the agent, e.g. the allowed
+// peers or the token, with the HTTP status matching the code of the error
+func (agent *Agent) checkHTTP(handler http.Handler) http.Handler {
+	if len(agent.httpChecks) == 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, check := range agent.httpChecks {
+			if err := check(r); err != nil {
+				code := http.StatusForbidden
+				if status.Code(err) == codes.Unauthenticated {
+					code = http.StatusUnauthorized
+				}
+				http.Error(w, status.Convert(err).Message(), code)
+				return
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// stopHTTP will stop the enabled HTTP listeners, see `httpEndpoint.stop()`
+func (agent *Agent) stopHTTP(graceful bool, timeout time.Duration) {
+	for _, endpoint := range agent.httpEndpoints() {
+		endpoint.stop(graceful, timeout)
+	}
+}
+
+func (endpoint *httpEndpoint) addr() *net.TCPAddr {
+	if endpoint == nil || endpoint.listen == nil {
+		return nil
+	}
+	return endpoint.listen.Addr().(*net.TCPAddr)
+}
+
+func (endpoint *httpEndpoint) start(handler http.Handler) error {
+	listen, err := net.Listen("tcp", endpoint.address)
+	if err != nil {
+		return err
+	}
+	endpoint.listen = listen
+	endpoint.server = &http.Server{Handler: handler}
+	go func() {
+		_ = endpoint.server.Serve(listen)
+	}()
+	return nil
+}
+
+// stop will stop the listener. If graceful is set it waits up to timeout, or without limit if timeout is not positive,
+// for the running requests
+func (endpoint *httpEndpoint) stop(graceful bool, timeout time.Duration) {
+	if endpoint.server == nil {
+		return
+	}
+	if !graceful {
+		_ = endpoint.server.Close()
+		return
+	}
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if endpoint.server.Shutdown(ctx) != nil {
+		_ = endpoint.server.Close()
+	}
+}
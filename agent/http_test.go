@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/pprof/profile"
+)
+
+// getHTTP will GET path from the address of the HTTP listener, with authorization as "Authorization" header if it is
+// not empty
+func getHTTP(t *testing.T, addr, path, authorization string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "http://"+addr+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestHTTPPprofHeap(t *testing.T) {
+	agent, _, _ := newTestAgent(t, WithHTTPPprof("127.0.0.1:0"))
+
+	resp := getHTTP(t, agent.HTTPAddr().String(), "/debug/pprof/heap", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /debug/pprof/heap: got %s", resp.Status)
+	}
+	_, err := profile.Parse(resp.Body)
+	if err != nil {
+		t.Errorf("heap profile served over HTTP: %v", err)
+	}
+}
+
+func TestHTTPChecks(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		option        *ServerOption
+		authorization string
+		code          int
+	}{
+		{"allowed peer", ServerWithAllowedPeers("127.0.0.0/8"), "", http.StatusOK},
+		{"denied peer", ServerWithAllowedPeers("10.0.0.0/8"), "", http.StatusForbidden},
+		{"valid token", ServerAuthTypeToken("secret"), "Bearer secret", http.StatusOK},
+		{"missing token", ServerAuthTypeToken("secret"), "", http.StatusUnauthorized},
+		{"wrong token", ServerAuthTypeToken("secret"), "Bearer wrong", http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			agent, err := NewAgent(WithHTTPPprof("127.0.0.1:0"), WithPrometheus("127.0.0.1:0"), tc.option)
+			if err != nil {
+				t.Fatal(err)
+			}
+			startTestAgent(t, agent)
+
+			for _, endpoint := range []struct{ addr, path string }{
+				{agent.HTTPAddr().String(), "/debug/pprof/cmdline"},
+				{agent.MetricsAddr().String(), "/metrics"},
+			} {
+				resp := getHTTP(t, endpoint.addr, endpoint.path, tc.authorization)
+				if resp.StatusCode != tc.code {
+					t.Errorf("GET %s: got %s, want %d", endpoint.path, resp.Status, tc.code)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package agent
+
+import (
+	"github.com/google/pprof/profile"
+)
+
+// filterLabels will keep only the samples of p which have every label of filters with the filtered value, e.g. the
+// samples of code run with `pprof.Do(ctx, pprof.Labels("request", "checkout"), ...)` for {"request": "checkout"}
+func filterLabels(p *profile.Profile, filters map[string]string) {
+	samples := p.Sample[:0]
+	for _, sample := range p.Sample {
+		if matchLabels(sample, filters) {
+			samples = append(samples, sample)
+		}
+	}
+	p.Sample = samples
+}
+
+func matchLabels(sample *profile.Sample, filters map[string]string) bool {
+	for key, value := range filters {
+		found := false
+		for _, v := range sample.Label[key] {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/pprof/profile"
+)
+
+func TestWriteFilteredLabels(t *testing.T) {
+	function := &profile.Function{ID: 1, Name: "main.handle"}
+	location := &profile.Location{ID: 1, Line: []profile.Line{{Function: function}}}
+	sample := func(value int64, labels map[string][]string) *profile.Sample {
+		return &profile.Sample{Location: []*profile.Location{location}, Value: []int64{value}, Label: labels}
+	}
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "samples", Unit: "count"}},
+		Sample: []*profile.Sample{
+			sample(1, map[string][]string{"request": {"checkout"}, "region": {"eu"}}),
+			sample(2, map[string][]string{"request": {"checkout"}, "region": {"us"}}),
+			sample(4, map[string][]string{"request": {"search"}, "region": {"eu"}}),
+			sample(8, nil),
+		},
+		Location: []*profile.Location{location},
+		Function: []*profile.Function{function},
+	}
+	var in bytes.Buffer
+	err := p.Write(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	_, err = writeFiltered(&in, &out, "goroutine", "", map[string]string{"request": "checkout", "region": "eu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered, err := profile.Parse(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered.Sample) != 1 || filtered.Sample[0].Value[0] != 1 {
+		t.Errorf("samples matching the labels: got %v, want the sample of value 1", filtered.Sample)
+	}
+}
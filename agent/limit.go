@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithMaxConcurrentProfiles function will create a GRPC Profile Agent option which limits the number of profile and
+// binary dump streams running at the same time to n. Additional requests are rejected with
+// `codes.ResourceExhausted` instead of waiting
+func WithMaxConcurrentProfiles(n int) *ServerOption {
+	if n <= 0 {
+		return &ServerOption{error: errors.New("maximum concurrent profiles must be positive")}
+	}
+	semaphore := make(chan struct{}, n)
+	return &ServerOption{option: grpc.ChainStreamInterceptor(
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if !profileMethods[info.FullMethod] && info.FullMethod != "/proto.ProfileService/BinaryDump" {
+				return handler(srv, ss)
+			}
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+				return handler(srv, ss)
+			default:
+				return status.Errorf(codes.ResourceExhausted, "too many concurrent profiles, the limit is %d", n)
+			}
+		},
+	)}
+}
+
+// rateLimitExempt lists the cheap RPCs which are not rate limited, so clients can still connect and health checks keep
+// working while the limit is reached
+var rateLimitExempt = map[string]bool{
+	"/proto.ProfileService/Ping":    true,
+	"/proto.ProfileService/Version": true,
+	"/proto.ProfileService/GetInfo": true,
+	"/grpc.health.v1.Health/Check":  true,
+}
+
+// tokenBucket allows rate requests per second with bursts of up to rate requests
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func (bucket *tokenBucket) take() bool {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	now := time.Now()
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+	if bucket.tokens > bucket.rate {
+		bucket.tokens = bucket.rate
+	}
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// ServerWithRateLimit function will create a GRPC Profile Agent option which allows perSecond RPCs per second, with
+// bursts of up to perSecond RPCs, across all clients. Excess RPCs are rejected with `codes.ResourceExhausted`. Ping,
+// Version, GetInfo and health checks are not limited
+func ServerWithRateLimit(perSecond int) *ServerOption {
+	if perSecond <= 0 {
+		return &ServerOption{error: errors.New("rate limit must be positive")}
+	}
+	bucket := &tokenBucket{rate: float64(perSecond), tokens: float64(perSecond), last: time.Now()}
+	allow := func(method string) error {
+		if rateLimitExempt[method] || bucket.take() {
+			return nil
+		}
+		return status.Errorf(codes.ResourceExhausted, "rate limit of %d requests per second exceeded", perSecond)
+	}
+
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.serverOptions = append(agent.serverOptions,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := allow(info.FullMethod); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := allow(info.FullMethod); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}),
+		)
+	}}
+}
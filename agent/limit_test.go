@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMaxConcurrentProfiles(t *testing.T) {
+	const n = 2
+	_, client, _ := newTestAgent(t, WithMaxConcurrentProfiles(n))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The binary dumps are not read to the end, so they keep running until the context is cancelled
+	for i := 0; i < n; i++ {
+		stream, err := client.BinaryDump(ctx, &empty.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stream, err := client.BinaryDump(ctx, &empty.Empty{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Errorf("download above the limit: got %v (%v), want %v", code, err, codes.ResourceExhausted)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	_, client, _ := newTestAgent(t, ServerWithRateLimit(2))
+	ctx := context.Background()
+
+	var accepted, rejected int
+	for i := 0; i < 10; i++ {
+		_, err := client.Get(ctx, &proto.GetProfileInputType{Variable: proto.ProfileVariable_MemProfileRate})
+		switch status.Code(err) {
+		case codes.OK:
+			accepted++
+		case codes.ResourceExhausted:
+			rejected++
+		default:
+			t.Fatal(err)
+		}
+	}
+	if accepted == 0 || rejected == 0 {
+		t.Errorf("requests above the rate limit: %d accepted and %d rejected, want some of both", accepted, rejected)
+	}
+
+	// Ping is exempt from the limit
+	_, err := client.Ping(ctx, &empty.Empty{})
+	if err != nil {
+		t.Errorf("ping above the rate limit: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// Logger is the interface of the loggers used by the GRPC Profile Agent. Printf like functions of any logging library
+// can be adapted with `LoggerFunc`, e.g. `LoggerFunc(log.Printf)` or `LoggerFunc(sugaredLogger.Infof)`
+type Logger interface {
+	Logf(format string, args ...interface{})
+}
+
+// LoggerFunc will adapt a printf like function to a `Logger`
+type LoggerFunc func(format string, args ...interface{})
+
+// Logf will call function
+func (function LoggerFunc) Logf(format string, args ...interface{}) {
+	function(format, args...)
+}
+
+// ServerWithLogger function will create a GRPC Profile Agent option to log every RPC with its method, the address of
+// the peer, its duration and its error, as an audit trail of who collected which profile and when. Panics recovered in
+// RPCs are logged to logger as well
+func ServerWithLogger(logger Logger) *ServerOption {
+	if logger == nil {
+		return &ServerOption{error: errors.New("logger can not be nil")}
+	}
+	logRPC := func(ctx context.Context, method string, start time.Time, err error) {
+		address := "unknown"
+		if p, ok := peer.FromContext(ctx); ok {
+			address = p.Addr.String()
+		}
+		logger.Logf("grpc-profile: %s from %s took %v, error: %v", method, address, time.Since(start), err)
+	}
+
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.logger = logger
+		agent.serverOptions = append(agent.serverOptions,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				start := time.Now()
+				resp, err := handler(ctx, req)
+				logRPC(ctx, info.FullMethod, start, err)
+				return resp, err
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				start := time.Now()
+				err := handler(srv, ss)
+				logRPC(ss.Context(), info.FullMethod, start, err)
+				return err
+			}),
+		)
+	}}
+}
+
+// logf will log to the logger of the agent, or to the standard logger if it has none
+func (agent *Agent) logf(format string, args ...interface{}) {
+	if agent.logger != nil {
+		agent.logger.Logf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
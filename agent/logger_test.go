@@ -0,0 +1,53 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// logRecorder is a `Logger` recording the logged lines
+type logRecorder struct {
+	lines []string
+	mutex sync.Mutex
+}
+
+func (recorder *logRecorder) Logf(format string, args ...interface{}) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.lines = append(recorder.lines, fmt.Sprintf(format, args...))
+}
+
+// Lines will return the lines logged so far
+func (recorder *logRecorder) Lines() []string {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]string(nil), recorder.lines...)
+}
+
+func TestServerWithLogger(t *testing.T) {
+	if _, err := NewAgent(ServerWithLogger(nil)); err == nil {
+		t.Error("nil logger: got no error")
+	}
+
+	logger := &logRecorder{}
+	_, client, _ := newTestAgent(t, ServerWithLogger(logger))
+
+	_, err := client.GetInfo(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := logger.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("logged lines for one RPC: got %q, want 1", lines)
+	}
+	for _, want := range []string{"/proto.ProfileService/GetInfo", "from 127.0.0.1:", "error: <nil>"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("logged line %q does not contain %q", lines[0], want)
+		}
+	}
+}
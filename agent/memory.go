@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cgroupMemoryLimitFiles lists the files holding the memory limit of the current cgroup (v2 first, then v1)
+var cgroupMemoryLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// cgroupMemoryLimit will return the memory limit of the cgroup the process is running in. The second return value
+// is false if there is no limit or it can not be detected
+func cgroupMemoryLimit() (uint64, bool) {
+	for _, file := range cgroupMemoryLimitFiles {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		value := strings.TrimSpace(string(content))
+		if value == "max" {
+			return 0, false
+		}
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		// cgroup v1 reports a huge page aligned value when the memory is not limited
+		if limit == 0 || limit >= 1<<62 {
+			return 0, false
+		}
+		return limit, true
+	}
+	return 0, false
+}
+
+// memoryGuardedMethods lists the RPCs rejected under memory pressure: the profile RPCs and the binary dump paths
+var memoryGuardedMethods = map[string]bool{
+	"/proto.ProfileService/BinaryDump": true,
+	"/proto.ProfileService/BinaryHash": true,
+}
+
+// checkMemoryPressure will return a `codes.ResourceExhausted` error if the heap is using more than thresholdPct
+// percent of limit
+func checkMemoryPressure(thresholdPct int, limit uint64) error {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	if memStats.HeapAlloc*100 > limit*uint64(thresholdPct) {
+		return status.Errorf(codes.ResourceExhausted, "heap usage %d bytes is above %d%% of the memory limit %d bytes",
+			memStats.HeapAlloc, thresholdPct, limit)
+	}
+	return nil
+}
+
+// WithMemoryPressureGuard function will create a GRPC Profile Agent option which rejects profile collection and binary
+// dumps when the heap is using more than thresholdPct percent of the cgroup memory limit. Collecting a profile
+// allocates memory, so this protects a process which is already close to be killed for running out of memory. The
+// limit is read once when the option is created, and the guard is a no-op when no memory limit is detected
+func WithMemoryPressureGuard(thresholdPct int) *ServerOption {
+	if thresholdPct <= 0 || thresholdPct > 100 {
+		return &ServerOption{error: errors.New("memory pressure threshold must be between 1 and 100")}
+	}
+	limit, ok := cgroupMemoryLimit()
+	if !ok {
+		return &ServerOption{}
+	}
+	return memoryPressureGuard(thresholdPct, limit)
+}
+
+// memoryPressureGuard will create the option of `WithMemoryPressureGuard()` for a memory limit of limit bytes
+func memoryPressureGuard(thresholdPct int, limit uint64) *ServerOption {
+	check := func(method string) error {
+		if profileMethods[method] || memoryGuardedMethods[method] {
+			return checkMemoryPressure(thresholdPct, limit)
+		}
+		return nil
+	}
+
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.serverOptions = append(agent.serverOptions,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := check(info.FullMethod); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := check(info.FullMethod); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}),
+		)
+	}}
+}
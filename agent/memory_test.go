@@ -0,0 +1,46 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMemoryPressureGuard(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name  string
+		limit uint64
+		code  codes.Code
+	}{
+		{"above threshold", 1, codes.ResourceExhausted},
+		{"below threshold", 1 << 50, codes.OK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, client, _ := newTestAgent(t, memoryPressureGuard(80, tc.limit))
+
+			stream, err := client.LookupProfile(ctx, &proto.LookupProfileInputType{ProfileType: proto.LookupProfile_profileTypeHeap})
+			if err == nil {
+				for err == nil {
+					_, err = stream.Recv()
+				}
+				if tc.code == codes.OK && err == io.EOF {
+					err = nil
+				}
+			}
+			if code := status.Code(err); code != tc.code {
+				t.Errorf("heap profile: got %v (%v), want %v", code, err, tc.code)
+			}
+
+			_, err = client.BinaryHash(ctx, &empty.Empty{})
+			if code := status.Code(err); code != tc.code {
+				t.Errorf("binary hash: got %v (%v), want %v", code, err, tc.code)
+			}
+		})
+	}
+}
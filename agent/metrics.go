@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"runtime/metrics"
+	"strconv"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// readRuntimeMetrics will read all metrics supported by `runtime/metrics`
+func readRuntimeMetrics() []metrics.Sample {
+	descriptions := metrics.All()
+	samples := make([]metrics.Sample, len(descriptions))
+	for i, description := range descriptions {
+		samples[i].Name = description.Name
+	}
+	metrics.Read(samples)
+	return samples
+}
+
+// GetRuntimeMetrics function will get all metrics supported by `runtime/metrics`, e.g. GC pause and scheduler latency
+// histograms
+func (agent *Agent) GetRuntimeMetrics(context.Context, *empty.Empty) (*proto.RuntimeMetricsType, error) {
+	samples := readRuntimeMetrics()
+	result := &proto.RuntimeMetricsType{Metrics: make([]*proto.RuntimeMetric, 0, len(samples))}
+	for _, sample := range samples {
+		metric := &proto.RuntimeMetric{Name: sample.Name}
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			metric.Kind = proto.RuntimeMetricKind_metricKindUint64
+			metric.Uint64 = sample.Value.Uint64()
+		case metrics.KindFloat64:
+			metric.Kind = proto.RuntimeMetricKind_metricKindFloat64
+			metric.Float64 = sample.Value.Float64()
+		case metrics.KindFloat64Histogram:
+			histogram := sample.Value.Float64Histogram()
+			metric.Kind = proto.RuntimeMetricKind_metricKindFloat64Histogram
+			metric.Counts = histogram.Counts
+			metric.Buckets = histogram.Buckets
+		default:
+			// Metrics unsupported by this version of the runtime
+			continue
+		}
+		result.Metrics = append(result.Metrics, metric)
+	}
+	return result, nil
+}
+
+// gcPercentMetric is the `runtime/metrics` name of the GC percent, it is available since Go 1.21
+const gcPercentMetric = "/gc/gogc:percent"
+
+// readGCPercent will read the GC percent without changing it. Runtimes without the GOGC metric report the value set
+// through the agent, or the one of the GOGC environment variable
+func (agent *Agent) readGCPercent() int {
+	sample := []metrics.Sample{{Name: gcPercentMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() == metrics.KindUint64 {
+		// GOGC=off is reported as -1 converted to uint64
+		return int(int64(sample[0].Value.Uint64()))
+	}
+	return agent.gcPercent
+}
+
+// envGCPercent will return the GC percent set with the GOGC environment variable, 100 by default and -1 for "off"
+func envGCPercent() int {
+	value := os.Getenv("GOGC")
+	if value == "off" {
+		return -1
+	}
+	if percent, err := strconv.Atoi(value); err == nil {
+		return percent
+	}
+	return 100
+}
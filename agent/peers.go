@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ServerWithAllowedPeers function will create a GRPC Profile Agent option to reject RPCs from source addresses outside
+// cidrs with `codes.PermissionDenied`. Entries are CIDRs like "10.0.0.0/8" or single IP addresses. Requests to the
+// HTTP listeners of the agent from other addresses are rejected with 403 Forbidden
+func ServerWithAllowedPeers(cidrs ...string) *ServerOption {
+	if len(cidrs) == 0 {
+		return &ServerOption{error: errors.New("at least one allowed peer is required")}
+	}
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return &ServerOption{error: fmt.Errorf("invalid allowed peer %q", cidr)}
+			}
+			network = &net.IPNet{IP: ip, Mask: net.CIDRMask(8*len(ip), 8*len(ip))}
+		}
+		networks = append(networks, network)
+	}
+
+	allowIP := func(ip net.IP) error {
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return nil
+			}
+		}
+		return status.Errorf(codes.PermissionDenied, "peer %s is not allowed", ip)
+	}
+	allow := func(ctx context.Context) error {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return status.Error(codes.PermissionDenied, "unknown peer")
+		}
+		addr, ok := p.Addr.(*net.TCPAddr)
+		if !ok {
+			return status.Errorf(codes.PermissionDenied, "peer %s is not allowed", p.Addr)
+		}
+		return allowIP(addr.IP)
+	}
+	allowHTTP := func(r *http.Request) error {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		ip := net.ParseIP(host)
+		if err != nil || ip == nil {
+			return status.Errorf(codes.PermissionDenied, "peer %s is not allowed", r.RemoteAddr)
+		}
+		return allowIP(ip)
+	}
+
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.httpChecks = append(agent.httpChecks, allowHTTP)
+		agent.serverOptions = append(agent.serverOptions,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := allow(ctx); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := allow(ss.Context()); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}),
+		)
+	}}
+}
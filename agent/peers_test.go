@@ -0,0 +1,31 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServerWithAllowedPeers(t *testing.T) {
+	for _, tc := range []struct {
+		cidr string
+		code codes.Code
+	}{
+		{"127.0.0.0/8", codes.OK},
+		{"10.0.0.0/8", codes.PermissionDenied},
+	} {
+		_, client, _ := newTestAgent(t, ServerWithAllowedPeers(tc.cidr))
+		_, err := client.GetInfo(context.Background(), &empty.Empty{})
+		if code := status.Code(err); code != tc.code {
+			t.Errorf("local peer with %s allowed: got %v (%v), want %v", tc.cidr, code, err, tc.code)
+		}
+	}
+
+	_, err := NewAgent(ServerWithAllowedPeers("10.0.0.0/33"))
+	if err == nil {
+		t.Error("malformed allowed peer was accepted")
+	}
+}
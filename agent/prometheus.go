@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// WithPrometheus function will create a GRPC Profile Agent option to serve Prometheus metrics under /metrics on
+// address: the Go runtime and process metrics, and the number of RPCs served by the agent by method and code.
+//
+// Like `WithHTTPPprof`, the listener serves plain HTTP without the TLS Auth type options and the limits of the agent,
+// and checks requests against `ServerWithAllowedPeers` and `ServerAuthTypeToken` if they are set. Bind address to
+// localhost unless the network is trusted
+func WithPrometheus(address string) *ServerOption {
+	rpcs := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_profile_rpcs_total",
+		Help: "Number of RPCs served by the GRPC Profile Agent.",
+	}, []string{"method", "code"})
+	count := func(method string, err error) {
+		rpcs.WithLabelValues(method, status.Code(err).String()).Inc()
+	}
+
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.metricsHTTP = &httpEndpoint{address: address, handler: func(*Agent) http.Handler {
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(
+				collectors.NewGoCollector(),
+				collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+				rpcs,
+			)
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+			return mux
+		}}
+		agent.serverOptions = append(agent.serverOptions,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				resp, err := handler(ctx, req)
+				count(info.FullMethod, err)
+				return resp, err
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				err := handler(srv, ss)
+				count(info.FullMethod, err)
+				return err
+			}),
+		)
+	}}
+}
+
+// MetricsAddr function will return the address of the Prometheus listener, or nil if it is not enabled
+func (agent *Agent) MetricsAddr() *net.TCPAddr {
+	return agent.metricsHTTP.addr()
+}
@@ -0,0 +1,36 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+func TestPrometheus(t *testing.T) {
+	agent, client, _ := newTestAgent(t, WithPrometheus("127.0.0.1:0"))
+	_, err := client.Ping(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := getHTTP(t, agent.MetricsAddr().String(), "/metrics", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /metrics: got %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, metric := range []string{
+		"go_goroutines ",
+		`grpc_profile_rpcs_total{code="OK",method="/proto.ProfileService/Ping"} 1`,
+	} {
+		if !strings.Contains(string(body), metric) {
+			t.Errorf("metrics do not contain %q", metric)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+)
+
+// defaultPushCPUDuration is the duration of the CPU profiles pushed by `StartPusher`, unless it is changed with
+// `PushCPUDuration` or the interval is shorter
+const defaultPushCPUDuration = 10 * time.Second
+
+// pushQueryKeys are the query parameters set by the pusher, labels can not use them
+var pushQueryKeys = map[string]bool{"type": true, "from": true, "until": true}
+
+// PushOption will configure the pusher started by `StartPusher`
+type PushOption func(*pusher) error
+
+type pusher struct {
+	url         *url.URL
+	labels      map[string]string
+	types       []string
+	cpuDuration time.Duration
+	client      *http.Client
+}
+
+// PushProfiles function will create a pusher option to select the profile types pushed on every interval. Valid types
+// are "cpu", "heap", "mutex", "block", "threadcreate", "goroutine" and "allocs". The default is "cpu" and "heap"
+func PushProfiles(types ...string) PushOption {
+	return func(p *pusher) error {
+		if len(types) == 0 {
+			return errors.New("at least one profile type is required")
+		}
+		for _, t := range types {
+			if !isPushProfile(t) {
+				return fmt.Errorf("profile type %q can not be pushed", t)
+			}
+		}
+		p.types = types
+		return nil
+	}
+}
+
+// PushCPUDuration function will create a pusher option to set the duration of the pushed CPU profiles. It must not be
+// longer than the interval
+func PushCPUDuration(d time.Duration) PushOption {
+	return func(p *pusher) error {
+		if d <= 0 {
+			return errors.New("CPU profile duration must be positive")
+		}
+		p.cpuDuration = d
+		return nil
+	}
+}
+
+// PushHTTPClient function will create a pusher option to upload the profiles with client, e.g. to set up TLS or
+// authentication towards the collector
+func PushHTTPClient(client *http.Client) PushOption {
+	return func(p *pusher) error {
+		if client == nil {
+			return errors.New("HTTP client can not be nil")
+		}
+		p.client = client
+		return nil
+	}
+}
+
+func isPushProfile(t string) bool {
+	if t == nonLookupStr[proto.NonLookupProfile_profileTypeCPU] {
+		return true
+	}
+	for _, name := range lookupStr {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// StartPusher function will collect profiles of the process every interval and POST them to ingestURL, for continuous
+// profiling with a remote collector. Every profile is uploaded as a gzipped pprof body with the query parameters type
+// (the profile type), from and until (unix seconds) and one parameter per label, e.g. service and instance. Upload
+// failures are logged and retried on the next interval. The pusher runs until the returned function is called
+func (agent *Agent) StartPusher(ingestURL string, interval time.Duration, labels map[string]string, options ...PushOption) (stop func(), err error) {
+	if interval <= 0 {
+		return nil, errors.New("push interval must be positive")
+	}
+	u, err := url.Parse(ingestURL)
+	if err != nil {
+		return nil, err
+	}
+	for key := range labels {
+		if pushQueryKeys[key] {
+			return nil, fmt.Errorf("label %q is reserved", key)
+		}
+	}
+
+	p := &pusher{
+		url:         u,
+		labels:      labels,
+		types:       []string{"cpu", "heap"},
+		cpuDuration: defaultPushCPUDuration,
+		client:      &http.Client{Timeout: interval},
+	}
+	if p.cpuDuration > interval {
+		p.cpuDuration = interval
+	}
+	for _, option := range options {
+		err = option(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.cpuDuration > interval {
+		return nil, errors.New("CPU profile duration can not be longer than the push interval")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			agent.push(ctx, p)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			wg.Wait()
+		})
+	}, nil
+}
+
+// push will collect and upload every profile type of the pusher once
+func (agent *Agent) push(ctx context.Context, p *pusher) {
+	for _, t := range p.types {
+		if ctx.Err() != nil {
+			return
+		}
+		var buf bytes.Buffer
+		from := time.Now()
+		err := agent.collectProfile(ctx, t, p.cpuDuration, &buf)
+		if err != nil {
+			agent.logf("grpc-profile: collecting %s profile to push: %v", t, err)
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		err = p.upload(ctx, t, from, time.Now(), &buf)
+		if err != nil {
+			agent.logf("grpc-profile: pushing %s profile: %v", t, err)
+		}
+	}
+}
+
+// collectProfile will write the profile of type t to buf, a CPU profile lasting cpuDuration
+func (agent *Agent) collectProfile(ctx context.Context, t string, cpuDuration time.Duration, buf *bytes.Buffer) error {
+	if t != nonLookupStr[proto.NonLookupProfile_profileTypeCPU] {
+		prof := pprof.Lookup(t)
+		if prof == nil {
+			return fmt.Errorf("unknown profile type %s", t)
+		}
+		return prof.WriteTo(buf, 0)
+	}
+
+	release, err := agent.acquireProfile(ctx, proto.NonLookupProfile_profileTypeCPU)
+	if err != nil {
+		return err
+	}
+	defer release()
+	startFunc, stopFunc, err := agent.nonLookupFuncs(proto.NonLookupProfile_profileTypeCPU)
+	if err != nil {
+		return err
+	}
+	return agent.runNonLookup(ctx, startFunc, stopFunc, cpuDuration, buf)
+}
+
+func (p *pusher) upload(ctx context.Context, t string, from, until time.Time, body *bytes.Buffer) error {
+	u := *p.url
+	query := u.Query()
+	for key, value := range p.labels {
+		query.Set(key, value)
+	}
+	query.Set("type", t)
+	query.Set("from", strconv.FormatInt(from.Unix(), 10))
+	query.Set("until", strconv.FormatInt(until.Unix(), 10))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("collector responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+)
+
+func TestStartPusher(t *testing.T) {
+	type upload struct {
+		query url.Values
+		body  []byte
+	}
+	uploads := make(chan upload, 10)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		select {
+		case uploads <- upload{r.URL.Query(), body}:
+		default:
+		}
+	}))
+	defer collector.Close()
+
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = agent.StartPusher(collector.URL, time.Second, map[string]string{"type": "web"}); err == nil {
+		t.Error("reserved label: got no error")
+	}
+	stop, err := agent.StartPusher(collector.URL+"/ingest", time.Second, map[string]string{"service": "checkout", "instance": "web-1"}, PushProfiles("heap"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	select {
+	case upload := <-uploads:
+		query := upload.query
+		for key, want := range map[string]string{"type": "heap", "service": "checkout", "instance": "web-1"} {
+			if got := query.Get(key); got != want {
+				t.Errorf("upload parameter %s: got %q, want %q", key, got, want)
+			}
+		}
+		if query.Get("from") == "" || query.Get("until") == "" {
+			t.Errorf("upload has no time range: %v", query)
+		}
+		if _, err = profile.ParseData(upload.body); err != nil {
+			t.Errorf("uploaded heap profile: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no profile was uploaded")
+	}
+}
@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// profileQueue runs the profiles of a type one at a time
+type profileQueue struct {
+	// admitted holds a token for the running profile and for every waiting one
+	admitted chan struct{}
+	running  chan struct{}
+}
+
+// ServerWithProfileQueue function will create a GRPC Profile Agent option to run CPU and trace profiles one at a time
+// instead of failing requests while a profile of the same type is running, as the runtime only supports one of each
+// at a time. Up to maxQueue requests per type wait for their turn, additional ones are rejected with
+// `codes.ResourceExhausted`
+func ServerWithProfileQueue(maxQueue int) *ServerOption {
+	if maxQueue < 0 {
+		return &ServerOption{error: errors.New("maximum queue length can not be negative")}
+	}
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.profileQueues = make(map[proto.NonLookupProfile]*profileQueue)
+		for profileType := range nonLookupStr {
+			agent.profileQueues[profileType] = &profileQueue{
+				admitted: make(chan struct{}, maxQueue+1),
+				running:  make(chan struct{}, 1),
+			}
+		}
+	}}
+}
+
+// acquireProfile will wait until a profile of the type can run if the profiles are queued. The returned function has
+// to be called once the profile is complete
+func (agent *Agent) acquireProfile(ctx context.Context, profileType proto.NonLookupProfile) (func(), error) {
+	queue, ok := agent.profileQueues[profileType]
+	if !ok {
+		return func() {}, nil
+	}
+
+	select {
+	case queue.admitted <- struct{}{}:
+	default:
+		return nil, status.Errorf(codes.ResourceExhausted, "too many queued %s profiles", nonLookupStr[profileType])
+	}
+	select {
+	case queue.running <- struct{}{}:
+	case <-ctx.Done():
+		<-queue.admitted
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return func() {
+		<-queue.running
+		<-queue.admitted
+	}, nil
+}
@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/pprof/profile"
+)
+
+func TestProfileQueue(t *testing.T) {
+	const (
+		n        = 3
+		duration = 200 * time.Millisecond
+	)
+	_, client, _ := newTestAgent(t, ServerWithProfileQueue(n-1))
+
+	var wg sync.WaitGroup
+	contents := make([]bytes.Buffer, n)
+	finished := make([]time.Time, n)
+	errs := make([]error, n)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			stream, err := client.NonLookupProfile(context.Background(), &proto.NonLookupProfileInputType{
+				ProfileType: proto.NonLookupProfile_profileTypeCPU,
+				Duration:    ptypes.DurationProto(duration),
+			})
+			for err == nil {
+				var chunk *proto.FileChunk
+				chunk, err = stream.Recv()
+				if err == nil {
+					contents[i].Write(chunk.Content)
+				}
+			}
+			if err != io.EOF {
+				errs[i] = err
+			}
+			finished[i] = time.Now()
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range contents {
+		if errs[i] != nil {
+			t.Fatalf("queued CPU profile %d: %v", i, errs[i])
+		}
+		_, err := profile.Parse(&contents[i])
+		if err != nil {
+			t.Errorf("queued CPU profile %d: %v", i, err)
+		}
+	}
+	// The profiles run one after the other, so each one completes a profile duration after the previous one
+	sort.Slice(finished, func(i, j int) bool { return finished[i].Before(finished[j]) })
+	previous := start
+	for i, end := range finished {
+		if gap := end.Sub(previous); gap < duration*3/4 {
+			t.Errorf("queued CPU profile %d completed %v after the previous one, want about %v", i, gap, duration)
+		}
+		previous = end
+	}
+}
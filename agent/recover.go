@@ -0,0 +1,32 @@
+package agent
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recoverInterceptors will return the interceptors converting a panic in an RPC into a `codes.Internal` error, so it
+// does not crash the process the agent is profiling. They are installed ahead of all other interceptors
+func (agent *Agent) recoverInterceptors() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+			defer agent.recoverRPC(info.FullMethod, &err)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+			defer agent.recoverRPC(info.FullMethod, &err)
+			return handler(srv, ss)
+		}),
+	}
+}
+
+func (agent *Agent) recoverRPC(method string, err *error) {
+	if r := recover(); r != nil {
+		agent.logf("grpc-profile: panic in %s: %v\n%s", method, r, debug.Stack())
+		*err = status.Errorf(codes.Internal, "panic in %s: %v", method, r)
+	}
+}
@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serverWithPanic will create an option making the unary and stream RPCs of method panic
+func serverWithPanic(method string) *ServerOption {
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.serverOptions = append(agent.serverOptions,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if info.FullMethod == method {
+					panic("test panic")
+				}
+				return handler(ctx, req)
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if info.FullMethod == method {
+					panic("test panic")
+				}
+				return handler(srv, ss)
+			}),
+		)
+	}}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	logger := &logRecorder{}
+	_, client, _ := newTestAgent(t, ServerWithLogger(logger), serverWithPanic("/proto.ProfileService/GetInfo"), serverWithPanic("/proto.ProfileService/LookupProfile"))
+	ctx := context.Background()
+
+	_, err := client.GetInfo(ctx, &empty.Empty{})
+	if code := status.Code(err); code != codes.Internal {
+		t.Errorf("panicking GetInfo: got %v (%v), want %v", code, err, codes.Internal)
+	}
+	stream, err := client.LookupProfile(ctx, &proto.LookupProfileInputType{ProfileType: proto.LookupProfile_profileTypeHeap})
+	if err == nil {
+		err = drain(stream)
+	}
+	if code := status.Code(err); code != codes.Internal {
+		t.Errorf("panicking LookupProfile: got %v (%v), want %v", code, err, codes.Internal)
+	}
+
+	// The connection survives the panics
+	if _, err = client.Ping(ctx, &empty.Empty{}); err != nil {
+		t.Errorf("ping after the panics: %v", err)
+	}
+	var logged bool
+	for _, line := range logger.Lines() {
+		if strings.Contains(line, "panic in /proto.ProfileService/GetInfo: test panic") && strings.Contains(line, "goroutine ") {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Errorf("no stack trace of the GetInfo panic was logged: %q", logger.Lines())
+	}
+}
@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+// rpcStats counts the RPCs served by the agent, they are reported by `GetInfo()`
+type rpcStats struct {
+	active int64
+
+	mutex sync.Mutex
+	total map[string]uint64
+}
+
+// begin will count an RPC of method, the returned function has to be called once it completes
+func (stats *rpcStats) begin(method string) func() {
+	atomic.AddInt64(&stats.active, 1)
+	stats.mutex.Lock()
+	if stats.total == nil {
+		stats.total = make(map[string]uint64)
+	}
+	stats.total[method]++
+	stats.mutex.Unlock()
+	return func() {
+		atomic.AddInt64(&stats.active, -1)
+	}
+}
+
+// snapshot will return the number of RPCs in flight and a copy of the number of RPCs served per method
+func (stats *rpcStats) snapshot() (int64, map[string]uint64) {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+	total := make(map[string]uint64, len(stats.total))
+	for method, n := range stats.total {
+		total[method] = n
+	}
+	return atomic.LoadInt64(&stats.active), total
+}
+
+// statsInterceptors will return the interceptors counting the RPCs in `agent.rpcStats`
+func (agent *Agent) statsInterceptors() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			defer agent.rpcStats.begin(info.FullMethod)()
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			defer agent.rpcStats.begin(info.FullMethod)()
+			return handler(srv, ss)
+		}),
+	}
+}
@@ -0,0 +1,38 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+func TestRPCStats(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Ping(ctx, &empty.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := client.GetInfo(ctx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ActiveRPCs != 1 {
+		t.Errorf("active RPCs while GetInfo is served: got %d, want 1", info.ActiveRPCs)
+	}
+	if n := info.TotalRPCs["/proto.ProfileService/Ping"]; n != 3 {
+		t.Errorf("total Ping RPCs: got %d, want 3", n)
+	}
+
+	info, err = client.GetInfo(ctx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := info.TotalRPCs["/proto.ProfileService/GetInfo"]; n != 2 {
+		t.Errorf("total GetInfo RPCs reported by the second GetInfo: got %d, want 2", n)
+	}
+}
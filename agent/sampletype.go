@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"io"
+
+	"github.com/google/pprof/profile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeFiltered will write the protobuf profile named name read from r to writer keeping only the values of the sample
+// type named sampleType, if it is not empty, and the samples matching all of labelFilters. Samples left without a
+// value are dropped. The number of samples written is returned
+func writeFiltered(r io.Reader, writer io.Writer, name, sampleType string, labelFilters map[string]string) (int, error) {
+	p, err := profile.Parse(r)
+	if err != nil {
+		return 0, err
+	}
+	if sampleType != "" {
+		err = selectSampleType(p, name, sampleType)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(labelFilters) != 0 {
+		filterLabels(p, labelFilters)
+	}
+	return len(p.Sample), p.Compact().Write(writer)
+}
+
+// selectSampleType will keep only the values of the sample type named sampleType in p
+func selectSampleType(p *profile.Profile, name, sampleType string) error {
+	index := -1
+	for i, st := range p.SampleType {
+		if st.Type == sampleType {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return status.Errorf(codes.InvalidArgument, "profile %s has no sample type %q", name, sampleType)
+	}
+
+	p.SampleType = p.SampleType[index : index+1]
+	p.DefaultSampleType = sampleType
+	samples := p.Sample[:0]
+	for _, sample := range p.Sample {
+		sample.Value = sample.Value[index : index+1]
+		if sample.Value[0] != 0 {
+			samples = append(samples, sample)
+		}
+	}
+	p.Sample = samples
+	return nil
+}
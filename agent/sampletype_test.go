@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/google/pprof/profile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLookupSampleType(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+	ctx := context.Background()
+
+	lookup := func(in *proto.LookupProfileInputType) ([]byte, error) {
+		stream, err := client.LookupProfile(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return buf.Bytes(), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(chunk.Content)
+		}
+	}
+
+	content, err := lookup(&proto.LookupProfileInputType{ProfileType: proto.LookupProfile_profileTypeHeap, SampleType: "alloc_space"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := profile.ParseData(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.SampleType) != 1 || p.SampleType[0].Type != "alloc_space" {
+		t.Errorf("sample types: got %v, want only alloc_space", p.SampleType)
+	}
+	if p.DefaultSampleType != "alloc_space" {
+		t.Errorf("default sample type: got %q, want %q", p.DefaultSampleType, "alloc_space")
+	}
+	for _, sample := range p.Sample {
+		if len(sample.Value) != 1 || sample.Value[0] == 0 {
+			t.Fatalf("sample values: got %v, want one value other than 0", sample.Value)
+		}
+	}
+
+	for _, in := range []*proto.LookupProfileInputType{
+		{ProfileType: proto.LookupProfile_profileTypeHeap, SampleType: "missing"},
+		{ProfileType: proto.LookupProfile_profileTypeHeap, SampleType: "alloc_space", Debug: 1},
+	} {
+		if _, err = lookup(in); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("sample type %q with debug %d: got %v, want %v", in.SampleType, in.Debug, err, codes.InvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// signalCPUDuration is the duration of the CPU profiles collected by `StartSignalTrigger`
+const signalCPUDuration = 10 * time.Second
+
+// StartSignalTrigger function will collect a profile of profileType into dir every time the process receives sig, e.g.
+// `syscall.SIGUSR1`, so an operator can capture a transient spike without a connected client. Valid types are the ones
+// of `PushProfiles`, a CPU profile lasts 10 seconds. Every profile is written as "<type>-<time>.pb.gz", failures are
+// logged. The trigger runs until the returned function is called
+func (agent *Agent) StartSignalTrigger(sig os.Signal, profileType string, dir string) (stop func(), err error) {
+	if sig == nil {
+		return nil, errors.New("signal can not be nil")
+	}
+	if !isPushProfile(profileType) {
+		return nil, fmt.Errorf("profile type %q can not be collected on a signal", profileType)
+	}
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, sig)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				err := agent.collectToDir(ctx, profileType, dir)
+				if err != nil {
+					agent.logf("grpc-profile: collecting %s profile on %v: %v", profileType, sig, err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			cancel()
+			wg.Wait()
+		})
+	}, nil
+}
+
+// collectToDir will write a profile of type t to a new file in dir
+func (agent *Agent) collectToDir(ctx context.Context, t string, dir string) error {
+	var buf bytes.Buffer
+	err := agent.collectProfile(ctx, t, signalCPUDuration, &buf)
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		// The CPU profile is incomplete
+		return nil
+	}
+	// The profile is renamed once written, so an incomplete file is never seen under its final name
+	name := filepath.Join(dir, t+"-"+time.Now().UTC().Format("20060102T150405.000000000Z")+".pb.gz")
+	err = ioutil.WriteFile(name+".tmp", buf.Bytes(), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
@@ -0,0 +1,53 @@
+//go:build !windows
+// +build !windows
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+)
+
+func TestSignalTrigger(t *testing.T) {
+	agent, err := NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	stop, err := agent.StartSignalTrigger(syscall.SIGUSR1, "heap", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	err = syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for deadline := time.Now().Add(5 * time.Second); len(files) == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		files, err = filepath.Glob(filepath.Join(dir, "heap-*.pb.gz"))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(files) != 1 {
+		t.Fatalf("heap profiles written on the signal: got %d, want 1", len(files))
+	}
+
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	_, err = profile.Parse(f)
+	if err != nil {
+		t.Errorf("written heap profile: %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package agent
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"runtime/pprof"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/pprof/profile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Names of the entries of a snapshot archive
+const (
+	snapshotCPU       = "cpu.pb.gz"
+	snapshotHeap      = "heap.pb.gz"
+	snapshotGoroutine = "goroutine.txt"
+	snapshotInfo      = "info.json"
+	snapshotManifest  = "manifest.json"
+)
+
+// SnapshotEntry will store information about an entry of a snapshot archive. The last entry of the archive,
+// "manifest.json", lists all the other entries, so tools can enumerate them without opening each one
+type SnapshotEntry struct {
+	Name string
+	Type string
+	Size int64
+	// Samples is the number of samples of a profile, or the number of goroutines in the goroutine dump
+	Samples int
+	Time    time.Time
+}
+
+// snapshotFile is an entry of a snapshot archive with its content
+type snapshotFile struct {
+	SnapshotEntry
+	content []byte
+}
+
+// Snapshot function will collect a CPU profile, a heap profile, a goroutine dump and the information about the agent
+// and stream them as one tar archive, for a quick overview of the process
+func (agent *Agent) Snapshot(inputType *proto.SnapshotInputType, profileServer proto.ProfileService_SnapshotServer) error {
+	ctx := profileServer.Context()
+	dur, err := ptypes.Duration(inputType.CPUDuration)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if dur <= 0 {
+		return status.Errorf(codes.InvalidArgument, "duration must be positive, got %v", dur)
+	}
+	dur, err = agent.profileDuration(dur)
+	if err != nil {
+		return err
+	}
+
+	err = sendMeta(profileServer, "snapshot", dur)
+	if err != nil {
+		return err
+	}
+
+	// The CPU profile stops early when the client cancels, so check the context between the stages
+	var cpu bytes.Buffer
+	err = agent.snapshotCPU(profileServer, dur, &cpu)
+	if err != nil {
+		return err
+	}
+	cpuTime := time.Now()
+	if err = ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	var heap bytes.Buffer
+	err = pprof.Lookup(lookupStr[proto.LookupProfile_profileTypeHeap]).WriteTo(&heap, 0)
+	if err != nil {
+		return err
+	}
+	heapTime := time.Now()
+	if err = ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	var goroutine bytes.Buffer
+	err = pprof.Lookup(lookupStr[proto.LookupProfile_profileTypeGoRoutine]).WriteTo(&goroutine, 2)
+	if err != nil {
+		return err
+	}
+	// Every goroutine of the dump starts with a "goroutine <id> [<state>]:" line after an empty line
+	goroutines := bytes.Count(goroutine.Bytes(), []byte("\n\ngoroutine ")) + 1
+	goroutineTime := time.Now()
+	if err = ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	info, err := agent.GetInfo(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	infoJSON, err := protojson.MarshalOptions{Multiline: true}.Marshal(info)
+	if err != nil {
+		return err
+	}
+	infoTime := time.Now()
+	if err = ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	entries := []snapshotFile{
+		{SnapshotEntry{Name: snapshotCPU, Type: "cpu", Time: cpuTime}, cpu.Bytes()},
+		{SnapshotEntry{Name: snapshotHeap, Type: "heap", Time: heapTime}, heap.Bytes()},
+		{SnapshotEntry{Name: snapshotGoroutine, Type: "goroutine", Samples: goroutines, Time: goroutineTime}, goroutine.Bytes()},
+		{SnapshotEntry{Name: snapshotInfo, Type: "info", Time: infoTime}, infoJSON},
+	}
+	manifest := make([]SnapshotEntry, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		entry.Size = int64(len(entry.content))
+		if entry.Name == snapshotCPU || entry.Name == snapshotHeap {
+			p, err := profile.ParseData(entry.content)
+			if err != nil {
+				return err
+			}
+			entry.Samples = len(p.Sample)
+		}
+		manifest[i] = entry.SnapshotEntry
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	entries = append(entries, snapshotFile{SnapshotEntry{Name: snapshotManifest, Time: time.Now()}, manifestJSON})
+
+	writer := agent.newStreamWriter(profileServer)
+	archive := tar.NewWriter(writer)
+	for _, entry := range entries {
+		err = archive.WriteHeader(&tar.Header{
+			Name:    entry.Name,
+			Mode:    0644,
+			Size:    int64(len(entry.content)),
+			ModTime: entry.Time,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = archive.Write(entry.content)
+		if err != nil {
+			return err
+		}
+	}
+	err = archive.Close()
+	if err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// snapshotCPU will collect the CPU profile of a snapshot into buf
+func (agent *Agent) snapshotCPU(profileServer proto.ProfileService_SnapshotServer, dur time.Duration, buf *bytes.Buffer) error {
+	startFunc, stopFunc, err := agent.nonLookupFuncs(proto.NonLookupProfile_profileTypeCPU)
+	if err != nil {
+		return err
+	}
+	release, err := agent.acquireProfile(profileServer.Context(), proto.NonLookupProfile_profileTypeCPU)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return agent.runNonLookup(profileServer.Context(), startFunc, stopFunc, dur, buf)
+}
@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ServerAuthTypeToken function will create a token Auth type GRPC Profile Agent option. Every RPC must carry an
+// "authorization: Bearer <token>" metadata, otherwise it is rejected with `codes.Unauthenticated`. Health checks are
+// not authenticated. The token is sent in clear text unless it is combined with a TLS Auth type option. Requests to the
+// HTTP listeners of the agent need the same "Authorization" header, otherwise they are rejected with 401 Unauthorized
+func ServerAuthTypeToken(token string) *ServerOption {
+	if token == "" {
+		return &ServerOption{error: errors.New("token can not be empty")}
+	}
+	expected := []byte("Bearer " + token)
+	authorize := func(ctx context.Context, method string) error {
+		if strings.HasPrefix(method, "/grpc.health.v1.Health/") {
+			return nil
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			if subtle.ConstantTimeCompare([]byte(value), expected) == 1 {
+				return nil
+			}
+		}
+		return status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	authorizeHTTP := func(r *http.Request) error {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) == 1 {
+			return nil
+		}
+		return status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+
+	return &ServerOption{apply: func(agent *Agent) {
+		agent.httpChecks = append(agent.httpChecks, authorizeHTTP)
+		agent.serverOptions = append(agent.serverOptions,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := authorize(ctx, info.FullMethod); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := authorize(ss.Context(), info.FullMethod); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}),
+		)
+	}}
+}
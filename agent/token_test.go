@@ -0,0 +1,43 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestServerAuthTypeToken(t *testing.T) {
+	_, client, _ := newTestAgent(t, ServerAuthTypeToken("secret"))
+
+	for _, tc := range []struct {
+		name          string
+		authorization []string
+		code          codes.Code
+	}{
+		{"accepted", []string{"authorization", "Bearer secret"}, codes.OK},
+		{"missing", nil, codes.Unauthenticated},
+		{"wrong", []string{"authorization", "Bearer wrong"}, codes.Unauthenticated},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tc.authorization...)
+
+			_, err := client.GetInfo(ctx, &empty.Empty{})
+			if code := status.Code(err); code != tc.code {
+				t.Errorf("GetInfo: got %v (%v), want %v", code, err, tc.code)
+			}
+
+			stream, err := client.LookupProfile(ctx, &proto.LookupProfileInputType{ProfileType: proto.LookupProfile_profileTypeHeap})
+			if err == nil {
+				err = drain(stream)
+			}
+			if code := status.Code(err); code != tc.code {
+				t.Errorf("LookupProfile: got %v (%v), want %v", code, err, tc.code)
+			}
+		})
+	}
+}
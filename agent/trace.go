@@ -0,0 +1,26 @@
+package agent
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// WithTraceRegion function will run fn inside a runtime/trace region of the given type. The region, together with
+// any task carried by ctx, is recorded in the execution traces collected by the agent and is shown by 'go tool
+// trace'. When no trace is running the overhead is a single check
+func WithTraceRegion(ctx context.Context, regionType string, fn func()) {
+	trace.WithRegion(ctx, regionType, fn)
+}
+
+// StartTraceTask function will create a runtime/trace task of the given type and return a context carrying it. Regions
+// and log messages created with the returned context are grouped under the task in the collected traces. The returned
+// function must be called to end the task
+func StartTraceTask(ctx context.Context, taskType string) (context.Context, func()) {
+	ctx, task := trace.NewTask(ctx, taskType)
+	return ctx, task.End
+}
+
+// TraceLog function will emit a message attached to the task carried by ctx into the running execution trace
+func TraceLog(ctx context.Context, category, message string) {
+	trace.Log(ctx, category, message)
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestTraceRegion(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+
+	// The application annotates its work while the trace runs
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+			ctx, end := StartTraceTask(context.Background(), "grpc-profile-test-task")
+			WithTraceRegion(ctx, "grpc-profile-test-region", func() {
+				TraceLog(ctx, "grpc-profile-test-category", "grpc-profile-test-message")
+			})
+			end()
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	stream, err := client.NonLookupProfile(context.Background(), &proto.NonLookupProfileInputType{
+		ProfileType: proto.NonLookupProfile_profileTypeTrace,
+		Duration:    ptypes.DurationProto(200 * time.Millisecond),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var trace bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		trace.Write(chunk.Content)
+	}
+	for _, annotation := range []string{"grpc-profile-test-task", "grpc-profile-test-region", "grpc-profile-test-message"} {
+		if !bytes.Contains(trace.Bytes(), []byte(annotation)) {
+			t.Errorf("collected trace does not contain %q", annotation)
+		}
+	}
+}
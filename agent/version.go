@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+const modulePath = "github.com/chanchal1987/grpc-profile"
+
+// features lists the optional capabilities of this agent. Clients use them to detect older agents
+var features = []string{
+	"lookup-debug",
+	"compression",
+	"continuous-profile",
+	"watch-info",
+	"binary-hash",
+	"get",
+	"gc-percent",
+	"max-procs",
+	"free-os-memory",
+	"profile-meta",
+	"reset",
+	"runtime-metrics",
+	"allocs",
+	"reset-all",
+	"set-multiple",
+	"sample-type",
+	"gc-before",
+	"snapshot",
+	"label-filter",
+}
+
+// version will return the version of this module recorded in the build information of the binary
+func version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+	return "(devel)"
+}
+
+// Version function will get the version and the supported features of the agent
+func (agent *Agent) Version(context.Context, *empty.Empty) (*proto.VersionType, error) {
+	return &proto.VersionType{Version: version(), Features: features}, nil
+}
@@ -0,0 +1,33 @@
+package agent
+
+import (
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// warningMetadataKey is the trailer key warnings about a profile are sent with, e.g. when it has no samples. Every
+// value is a serialized `google.rpc.Status` with code OK, the warning as message and an `errdetails.ErrorInfo` detail
+// carrying the reason of the warning
+const warningMetadataKey = "grpc-profile-warning-bin"
+
+// warningTrailer will create the trailer sending the warning message with reason
+func warningTrailer(reason, message string) (metadata.MD, error) {
+	detail, err := ptypes.MarshalAny(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return nil, err
+	}
+	value, err := protobuf.Marshal(&spb.Status{
+		Code:    int32(codes.OK),
+		Message: message,
+		Details: []*any.Any{detail},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metadata.Pairs(warningMetadataKey, string(value)), nil
+}
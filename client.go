@@ -1,22 +1,90 @@
 package profile
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/chanchal1987/grpc-profile/internal/tlsconfig"
 	"github.com/chanchal1987/grpc-profile/proto"
+	protobuf "github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-func receiveFileChunk(writer io.Writer, stream interface {
-	Recv() (*proto.FileChunk, error)
-}) (err error) {
+// chunkReader will read the content of the file chunks received from a stream
+type chunkReader struct {
+	stream interface {
+		Recv() (*proto.FileChunk, error)
+	}
+	buf    []byte
+	onMeta func(*proto.ProfileMeta)
+}
+
+func (reader *chunkReader) Read(p []byte) (int, error) {
+	for len(reader.buf) == 0 {
+		fc, err := reader.stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return 0, io.EOF
+			}
+			return 0, wrapError(err)
+		}
+		if fc.Meta != nil && reader.onMeta != nil {
+			reader.onMeta(fc.Meta)
+		}
+		reader.buf = fc.Content
+	}
+	n := copy(p, reader.buf)
+	reader.buf = reader.buf[n:]
+	return n, nil
+}
+
+// receiveBufferSize is the size of the buffer received profiles are written through
+const receiveBufferSize = 64 * 1024
+
+// receiveFileChunk will write the content of the received chunks into writer. The metadata chunk of a profile, if the
+// agent sends one, is passed to onMeta when it is not nil. The progress is reported to the function set with
+// `WithProgress()` on the context of the stream
+func receiveFileChunk(writer io.Writer, stream fileChunkStream, compressed bool, onMeta func(*proto.ProfileMeta)) (err error) {
+	if progress := progressFromContext(stream.Context()); progress != nil {
+		stream = &progressStream{fileChunkStream: stream, progress: progress}
+	}
+
+	// Chunks are small, so write them through a buffer to avoid a syscall per chunk when writing to a file
+	buffered := bufio.NewWriterSize(writer, receiveBufferSize)
+	defer func() {
+		if flushErr := buffered.Flush(); err == nil {
+			err = flushErr
+		}
+	}()
+	writer = buffered
+
+	if compressed {
+		var gz *gzip.Reader
+		gz, err = gzip.NewReader(&chunkReader{stream: stream, onMeta: onMeta})
+		if err != nil {
+			return
+		}
+		_, err = io.Copy(writer, gz)
+		return
+	}
+
 	var fc *proto.FileChunk
 
 	for {
@@ -26,9 +94,12 @@ func receiveFileChunk(writer io.Writer, stream interface {
 				err = nil
 				break
 			} else {
-				return
+				return wrapError(err)
 			}
 		}
+		if fc.Meta != nil && onMeta != nil {
+			onMeta(fc.Meta)
+		}
 		_, err = writer.Write(fc.Content)
 		if err != nil {
 			return
@@ -65,6 +136,11 @@ const (
 	// The profiler aims to sample an average of one blocking event per rate nanoseconds spent blocked.
 	// To include every blocking event in the profile, pass rate = 1. To turn off profiling entirely, pass rate <= 0.
 	BlockProfileRate
+
+	// GCPercent controls the garbage collection target percentage. A collection is triggered when the ratio of freshly
+	// allocated data to live data remaining after the previous collection reaches this percentage. A negative
+	// percentage disables garbage collection.
+	GCPercent
 )
 const (
 	// HeapType - Memory / Heap Profile Type
@@ -77,6 +153,8 @@ const (
 	ThreadCreateType
 	// GoRoutineType - GoRoutine Profile Type
 	GoRoutineType
+	// AllocsType - Allocations Profile Type. It is the heap profile with the allocated space as default sample type
+	AllocsType
 )
 const (
 	// CPUType - CPU profile type
@@ -86,11 +164,35 @@ const (
 	TraceType
 )
 
+var lookupTypeName = map[LookupType]string{
+	HeapType:         "heap",
+	MutexType:        "mutex",
+	BlockType:        "block",
+	ThreadCreateType: "threadcreate",
+	GoRoutineType:    "goroutine",
+	AllocsType:       "allocs",
+}
+var nonLookupTypeName = map[NonLookupType]string{
+	CPUType:   "cpu",
+	TraceType: "trace",
+}
+
+// String function will return the pprof name of the LookupType
+func (t LookupType) String() string {
+	return lookupTypeName[t]
+}
+
+// String function will return the name of the NonLookupType
+func (t NonLookupType) String() string {
+	return nonLookupTypeName[t]
+}
+
 var lookupVariable = map[Variable]proto.ProfileVariable{
 	MemProfRate:          proto.ProfileVariable_MemProfileRate,
 	CPUProfRate:          proto.ProfileVariable_CPUProfileRate,
 	MutexProfileFraction: proto.ProfileVariable_MutexProfileFraction,
 	BlockProfileRate:     proto.ProfileVariable_BlockProfileRate,
+	GCPercent:            proto.ProfileVariable_GCPercent,
 }
 var lookupLookupType = map[LookupType]proto.LookupProfile{
 	HeapType:         proto.LookupProfile_profileTypeHeap,
@@ -98,6 +200,7 @@ var lookupLookupType = map[LookupType]proto.LookupProfile{
 	BlockType:        proto.LookupProfile_profileTypeBlock,
 	ThreadCreateType: proto.LookupProfile_profileTypeThreadCreate,
 	GoRoutineType:    proto.LookupProfile_profileTypeGoRoutine,
+	AllocsType:       proto.LookupProfile_profileTypeAllocs,
 }
 var lookupNonLookupType = map[NonLookupType]proto.NonLookupProfile{
 	CPUType:   proto.NonLookupProfile_profileTypeCPU,
@@ -170,6 +273,57 @@ type MemStats struct {
 	NumForcedGC  uint32
 }
 
+// ProfileMeta will store the metadata the agent sends along with a profile
+type ProfileMeta struct {
+	ProfileType string
+	Time        time.Time
+	Duration    time.Duration
+	Hostname    string
+	GoVersion   string
+
+	// Warnings are sent by the agent once the profile is complete, e.g. when a lookup profile has no samples
+	Warnings []string
+
+	// WarningStatuses are the statuses the warnings are sent with, in the same order. Their details carry an
+	// `errdetails.ErrorInfo` with the reason of the warning, e.g. "PROFILE_EMPTY"
+	WarningStatuses []*status.Status
+}
+
+// warningMetadataKey is the trailer key the agent sends warnings about a profile with, every value is a serialized
+// `google.rpc.Status`
+const warningMetadataKey = "grpc-profile-warning-bin"
+
+// addWarnings will add the warnings of trailer to meta
+func (meta *ProfileMeta) addWarnings(trailer metadata.MD) {
+	for _, value := range trailer.Get(warningMetadataKey) {
+		var st spb.Status
+		if err := protobuf.Unmarshal([]byte(value), &st); err != nil {
+			continue
+		}
+		warning := status.FromProto(&st)
+		meta.Warnings = append(meta.Warnings, warning.Message())
+		meta.WarningStatuses = append(meta.WarningStatuses, warning)
+	}
+}
+
+func metaFromProto(meta *proto.ProfileMeta) (*ProfileMeta, error) {
+	t, err := ptypes.Timestamp(meta.Time)
+	if err != nil {
+		return nil, err
+	}
+	d, err := ptypes.Duration(meta.Duration)
+	if err != nil {
+		return nil, err
+	}
+	return &ProfileMeta{
+		ProfileType: meta.ProfileType,
+		Time:        t,
+		Duration:    d,
+		Hostname:    meta.Hostname,
+		GoVersion:   meta.GoVersion,
+	}, nil
+}
+
 // InfoType will store all informations about the agent
 type InfoType struct {
 	GOOS           string
@@ -182,6 +336,14 @@ type InfoType struct {
 	ProcessStats   ProcessStats
 	MemStats       MemStats
 	MemProfileRate int
+
+	// NumUserGoroutines is an approximation of NumGoroutine excluding the goroutines started by the Go runtime
+	NumUserGoroutines int
+
+	// ActiveRPCs is the number of RPCs the agent is serving, including the one reporting it
+	ActiveRPCs int64
+	// TotalRPCs is the number of RPCs served by the agent since it started, by full method name
+	TotalRPCs map[string]uint64
 }
 
 // Client will store GRPC Profile Client instance. We can create a instance of the client using `NewClient()` function
@@ -191,17 +353,44 @@ type Client struct {
 	ctx         context.Context
 	callOptions []grpc.CallOption
 	dialOptions []grpc.DialOption
+	compress    bool
+
+	transportSet bool
+
+	dialTimeout  time.Duration
+	retry        *retryPolicy
+	profileSlack time.Duration
+
+	agentVersion     string
+	agentFeatures    map[string]bool
+	requiredFeatures []string
+
+	// sessionChanges stores the value of every variable before this client changed it for the first time
+	sessionChanges map[Variable]int
+	sessionMutex   sync.Mutex
+
+	// serverAddress is the address of the agent the client is connected to
+	serverAddress string
+
+	// keepDir is the directory of the kept profiles, see `WithKeepDir()`
+	keepDir   string
+	keepMutex sync.Mutex
 }
 
 // DialOption will create a Dial Option for the GRPC Profile Client
 type DialOption struct {
 	option grpc.DialOption
+	apply  func(*Client)
 	error  error
+
+	// transport is set for the options choosing the transport security
+	transport bool
 }
 
 // CallOption will create a Call Option for the GRPC Profile Client
 type CallOption struct {
 	option grpc.CallOption
+	apply  func(*Client)
 	error  error
 }
 
@@ -213,7 +402,13 @@ func (client *Client) SetDialOption(option *DialOption) error {
 	if option.error != nil {
 		return option.error
 	}
-	client.dialOptions = append(client.dialOptions, option.option)
+	if option.option != nil {
+		client.dialOptions = append(client.dialOptions, option.option)
+	}
+	if option.apply != nil {
+		option.apply(client)
+	}
+	client.transportSet = client.transportSet || option.transport
 	return nil
 }
 
@@ -236,7 +431,12 @@ func (client *Client) SetCallOption(option *CallOption) error {
 	if option.error != nil {
 		return option.error
 	}
-	client.callOptions = append(client.callOptions, option.option)
+	if option.option != nil {
+		client.callOptions = append(client.callOptions, option.option)
+	}
+	if option.apply != nil {
+		option.apply(client)
+	}
 	return nil
 }
 
@@ -253,63 +453,237 @@ func (client *Client) SetCallOptions(options ...*CallOption) (err error) {
 
 // DialAuthTypeInsecure function will create a Insecure Auth type GRPC Profile Client Dial option
 func DialAuthTypeInsecure() *DialOption {
-	return &DialOption{option: grpc.WithInsecure()}
+	return &DialOption{option: grpc.WithInsecure(), transport: true}
 }
 
-// DialAuthTypeTLS function will create a TLS Secure Auth type GRPC Profile Client Dial option
-func DialAuthTypeTLS(certFile string) *DialOption {
-	cred, err := credentials.NewClientTLSFromFile(certFile, "")
+// DialAuthTypeTLS function will create a TLS Secure Auth type GRPC Profile Client Dial option. The server certificate
+// is verified against the certificates in certFile
+func DialAuthTypeTLS(certFile string, options ...TLSOption) *DialOption {
+	rootCAs, err := tlsconfig.LoadCertPool(certFile)
 	if err != nil {
 		return &DialOption{error: err}
 	}
-	return &DialOption{option: grpc.WithTransportCredentials(cred)}
+	return &DialOption{option: grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.New(&tls.Config{
+		RootCAs: rootCAs,
+	}, options))), transport: true}
 }
 
-// NewClient function will create a GRPC Profile Client instance
-func NewClient(ctx context.Context, serverAddress string, options ...*DialOption) (client *Client, err error) {
-	client = &Client{}
-	_ = client.SetDialOption(DialAuthTypeInsecure()) // Default insecure security
-
-	err = client.SetDialOptions(options...)
+// DialAuthTypeMutualTLS function will create a mutual TLS Auth type GRPC Profile Client Dial option. The client presents
+// the certificate in certFile and verifies the server certificate against the CAs in caFile
+func DialAuthTypeMutualTLS(certFile, keyFile, caFile string, options ...TLSOption) *DialOption {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
 	if err != nil {
-		return
+		return &DialOption{error: err}
 	}
+	rootCAs, err := tlsconfig.LoadCertPool(caFile)
+	if err != nil {
+		return &DialOption{error: err}
+	}
+	return &DialOption{option: grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.New(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+	}, options))), transport: true}
+}
 
-	err = client.Connect(ctx, serverAddress)
-	return
+// tokenCredentials will attach a bearer token to every RPC
+type tokenCredentials string
+
+func (token tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(token)}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// DialAuthTypeToken function will create a token Auth type GRPC Profile Client Dial option, sending token with every
+// RPC. It can be combined with a TLS Auth type option, without one the token is sent in clear text
+func DialAuthTypeToken(token string) *DialOption {
+	return &DialOption{option: grpc.WithPerRPCCredentials(tokenCredentials(token))}
+}
+
+// DialKeepalive function will create a GRPC Profile Client Dial option to ping the agent after interval without
+// activity and close the connection if the ping is not acknowledged within timeout, so a connection silently dropped
+// by a NAT or a firewall is detected before the next RPC hangs on it. If permitWithoutStream is set, pings are also
+// sent while no RPC is running. Sensible values are an interval of a few minutes (gRPC enforces at least 10 seconds)
+// and a timeout of 20 seconds. Agents reject pings more frequent than every 5 minutes, or pings without a running
+// RPC, unless they allow them with `agent.ServerKeepaliveEnforcement`
+func DialKeepalive(interval, timeout time.Duration, permitWithoutStream bool) *DialOption {
+	return &DialOption{option: grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                interval,
+		Timeout:             timeout,
+		PermitWithoutStream: permitWithoutStream,
+	})}
+}
+
+// DialMaxRecvMsgSize function will create a GRPC Profile Client Dial option to accept messages up to n bytes from
+// the agent instead of the gRPC default of 4 MB
+func DialMaxRecvMsgSize(n int) *DialOption {
+	if n <= 0 {
+		return &DialOption{error: errors.New("maximum message size must be positive")}
+	}
+	return &DialOption{option: grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(n))}
+}
+
+// DialMaxSendMsgSize function will create a GRPC Profile Client Dial option to send messages up to n bytes to the
+// agent
+func DialMaxSendMsgSize(n int) *DialOption {
+	if n <= 0 {
+		return &DialOption{error: errors.New("maximum message size must be positive")}
+	}
+	return &DialOption{option: grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(n))}
+}
+
+// DialTimeout function will create a GRPC Profile Client Dial option to wait up to d for the connection to the agent
+// to be established in `Connect()`, failing fast if the agent can not be reached. Without it the connection is
+// established in the background and failures surface on the first RPC
+func DialTimeout(d time.Duration) *DialOption {
+	if d <= 0 {
+		return &DialOption{error: errors.New("dial timeout must be positive")}
+	}
+	return &DialOption{apply: func(client *Client) {
+		client.dialTimeout = d
+	}}
+}
+
+// defaultProfileSlack is the time a non lookup profile may take beyond its duration, unless it is changed with
+// `DialProfileSlack`
+const defaultProfileSlack = 30 * time.Second
+
+// DialProfileSlack function will create a GRPC Profile Client Dial option to set how long `NonLookupProfile()` waits
+// beyond the duration of the profile before failing with `codes.DeadlineExceeded`, e.g. when the agent hangs. The
+// default is 30s, it has to cover the time a queued profile waits on the agent. 0 disables the deadline. The deadline
+// is only added if ctx has none
+func DialProfileSlack(slack time.Duration) *DialOption {
+	if slack < 0 {
+		return &DialOption{error: errors.New("profile slack can not be negative")}
+	}
+	return &DialOption{apply: func(client *Client) {
+		client.profileSlack = slack
+	}}
+}
+
+// WithCompression function will create a GRPC Profile Client Call option to request profiles gzip compressed on the
+// wire. They are decompressed transparently, so the written profiles are the same as without compression
+func WithCompression() *CallOption {
+	return &CallOption{apply: func(client *Client) {
+		client.compress = true
+	}}
+}
+
+// NewClient function will create a GRPC Profile Client instance. It is kept for compatibility, `Dial()` with
+// `ClientOption`s is preferred
+func NewClient(ctx context.Context, serverAddress string, options ...*DialOption) (*Client, error) {
+	clientOptions := make([]ClientOption, len(options))
+	for i, option := range options {
+		clientOptions[i] = WithDialOption(option)
+	}
+	return Dial(ctx, serverAddress, clientOptions...)
 }
 
-// Connect function will connect GRPC Profile Client to GRPC Profile Server
+// Connect function will connect GRPC Profile Client to GRPC Profile Server and fetch the version and the features of
+// the agent
 func (client *Client) Connect(ctx context.Context, serverAddress string) error {
-	conn, err := grpc.Dial(serverAddress, client.dialOptions...)
+	var conn *grpc.ClientConn
+	var err error
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryErrorInterceptor),
+		grpc.WithChainStreamInterceptor(streamErrorInterceptor),
+	}, client.dialOptions...)
+	if client.dialTimeout > 0 {
+		dialCtx, cancel := context.WithTimeout(ctx, client.dialTimeout)
+		defer cancel()
+		conn, err = grpc.DialContext(dialCtx, serverAddress, append(dialOptions, grpc.WithBlock())...)
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("could not connect to %s within %v: %w", serverAddress, client.dialTimeout, err)
+		}
+	} else {
+		conn, err = grpc.Dial(serverAddress, dialOptions...)
+	}
 	if err != nil {
 		return err
 	}
 	client.ctx = ctx
 	client.conn = conn
+	client.serverAddress = serverAddress
 	client.client = proto.NewProfileServiceClient(client.conn)
 
-	repl, err := client.client.Ping(ctx, &emptypb.Empty{}, client.callOptions...)
+	repl, err := client.service().Ping(ctx, &emptypb.Empty{}, client.callOptions...)
 	if err != nil {
 		return err
 	}
 	if repl.Message != "pong" {
 		return errors.New("unknown error")
 	}
-	return nil
+	return client.negotiate()
+}
+
+// Ping function will ping the agent and return the round-trip time
+func (client *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	repl, err := client.service().Ping(ctx, &emptypb.Empty{}, client.callOptions...)
+	if err != nil {
+		return 0, err
+	}
+	latency := time.Since(start)
+	if repl.Message != "pong" {
+		return 0, errors.New("unknown error")
+	}
+	return latency, nil
 }
 
 // Stop function will stop GRPC Profile Client
 func (client *Client) Stop() error {
-	return client.conn.Close()
+	if client.conn == nil {
+		return ErrNotConnected
+	}
+	err := client.conn.Close()
+	client.conn, client.client = nil, nil
+	return err
 }
 
 // GetInfo function will get current information about the agent
 func (client *Client) GetInfo(ctx context.Context) (*InfoType, error) {
-	info, err := client.client.GetInfo(ctx, &empty.Empty{}, client.callOptions...)
+	info, err := client.service().GetInfo(ctx, &empty.Empty{}, client.callOptions...)
 	if err != nil {
 		return nil, err
 	}
+	return infoFromProto(info)
+}
+
+// WatchInfo function will call fn with the information about the agent every interval until ctx is cancelled or fn
+// returns an error. It returns nil when ctx is cancelled
+func (client *Client) WatchInfo(ctx context.Context, interval time.Duration, fn func(*InfoType) error) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.service().WatchInfo(streamCtx, &proto.WatchInfoInputType{Interval: ptypes.DurationProto(interval)}, client.callOptions...)
+	if err != nil {
+		return err
+	}
+
+	for {
+		info, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		converted, err := infoFromProto(info)
+		if err != nil {
+			return err
+		}
+		err = fn(converted)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// infoFromProto will convert the agent information received over the wire
+func infoFromProto(info *proto.InfoType) (*InfoType, error) {
+	var err error
 	var modTimeL, modTime, lastGC, lastPause time.Time
 	var pauseTotalNs time.Duration
 	if info.ProcessStats.ExecutableLStat.ModeTime == nil {
@@ -434,22 +808,187 @@ func (client *Client) GetInfo(ctx context.Context) (*InfoType, error) {
 			NumGC:        info.MemStats.NumGC,
 			NumForcedGC:  info.MemStats.NumForcedGC,
 		},
-		MemProfileRate: int(info.MemProfileRate),
+		MemProfileRate:    int(info.MemProfileRate),
+		NumUserGoroutines: int(info.NumUserGoroutines),
+		ActiveRPCs:        info.ActiveRPCs,
+		TotalRPCs:         info.TotalRPCs,
 	}, nil
 }
 
+// WaitForInfo function will poll the agent information every interval until pred returns true for it, and return
+// that information. It returns the context error if ctx is done before the condition holds
+func (client *Client) WaitForInfo(ctx context.Context, pred func(*InfoType) bool, interval time.Duration) (*InfoType, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		info, err := client.GetInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if pred(info) {
+			return info, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // BinaryDump function will get a binary dump of the remote binary
 func (client *Client) BinaryDump(ctx context.Context, writer io.Writer) error {
-	stream, err := client.client.BinaryDump(ctx, &empty.Empty{}, client.callOptions...)
+	stream, err := client.service().BinaryDump(ctx, &empty.Empty{}, client.callOptions...)
 	if err != nil {
 		return err
 	}
-	return receiveFileChunk(writer, stream)
+	return receiveFileChunk(writer, stream, false, nil)
+}
+
+// BinaryHash function will get the hex encoded SHA-256 hash and the size of the remote binary
+func (client *Client) BinaryHash(ctx context.Context) (string, int64, error) {
+	hash, err := client.service().BinaryHash(ctx, &empty.Empty{}, client.callOptions...)
+	if err != nil {
+		return "", 0, err
+	}
+	return hash.SHA256, hash.Size, nil
+}
+
+func (client *Client) set(ctx context.Context, v Variable, r int) (int, error) {
+	val, err := client.service().Set(ctx, &proto.SetProfileInputType{Variable: lookupVariable[v], Rate: int32(r)}, client.callOptions...)
+	if err != nil {
+		return 0, err
+	}
+	return int(val.Value), nil
 }
 
 // Set function will set the GRPC Profile Variable
 func (client *Client) Set(ctx context.Context, v Variable, r int) (int, error) {
-	val, err := client.client.Set(ctx, &proto.SetProfileInputType{Variable: lookupVariable[v], Rate: int32(r)}, client.callOptions...)
+	prev, err := client.set(ctx, v, r)
+	if err != nil {
+		return 0, err
+	}
+
+	client.sessionMutex.Lock()
+	defer client.sessionMutex.Unlock()
+	if client.sessionChanges == nil {
+		client.sessionChanges = make(map[Variable]int)
+	}
+	if _, ok := client.sessionChanges[v]; !ok {
+		client.sessionChanges[v] = prev
+	}
+	return prev, nil
+}
+
+// SetMultiple function will set several GRPC Profile Variables in one call and return their previous values. The
+// variables are applied by the agent in the order of their `Variable` constants, e.g. CPUProfRate before
+// MutexProfileFraction
+func (client *Client) SetMultiple(ctx context.Context, values map[Variable]int) (map[Variable]int, error) {
+	variables := make([]Variable, 0, len(values))
+	for v := range values {
+		if _, ok := lookupVariable[v]; !ok {
+			return nil, fmt.Errorf("unknown variable %d", v)
+		}
+		variables = append(variables, v)
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i] < variables[j] })
+
+	input := &proto.VariablesType{Values: make([]*proto.VariableValue, len(variables))}
+	for i, v := range variables {
+		input.Values[i] = &proto.VariableValue{Variable: lookupVariable[v], Value: int32(values[v])}
+	}
+	prevValues, err := client.service().SetMultiple(ctx, input, client.callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	prev := variablesFromProto(prevValues)
+
+	client.sessionMutex.Lock()
+	defer client.sessionMutex.Unlock()
+	if client.sessionChanges == nil {
+		client.sessionChanges = make(map[Variable]int)
+	}
+	for v, value := range prev {
+		if _, ok := client.sessionChanges[v]; !ok {
+			client.sessionChanges[v] = value
+		}
+	}
+	return prev, nil
+}
+
+// ResetSessionChanges function will restore every variable changed with `Set()` through this client to the value it
+// had before the first change. Variables this client never changed, e.g. ones configured by the application, are
+// left untouched
+func (client *Client) ResetSessionChanges(ctx context.Context) error {
+	client.sessionMutex.Lock()
+	defer client.sessionMutex.Unlock()
+	for v, prev := range client.sessionChanges {
+		_, err := client.set(ctx, v, prev)
+		if err != nil {
+			return err
+		}
+		delete(client.sessionChanges, v)
+	}
+	return nil
+}
+
+// Get function will get the current value of the GRPC Profile Variable
+func (client *Client) Get(ctx context.Context, v Variable) (int, error) {
+	val, err := client.service().Get(ctx, &proto.GetProfileInputType{Variable: lookupVariable[v]}, client.callOptions...)
+	if err != nil {
+		return 0, err
+	}
+	return int(val.Value), nil
+}
+
+// Reset function will restore the GRPC Profile Variable to its value when the agent was created and return the
+// previous value
+func (client *Client) Reset(ctx context.Context, v Variable) (int, error) {
+	val, err := client.service().Reset(ctx, &proto.ResetProfileInputType{Variable: lookupVariable[v]}, client.callOptions...)
+	if err != nil {
+		return 0, err
+	}
+
+	// The variable is back to its initial value, so `ResetSessionChanges()` must not restore it
+	client.sessionMutex.Lock()
+	defer client.sessionMutex.Unlock()
+	delete(client.sessionChanges, v)
+	return int(val.Value), nil
+}
+
+// ResetAll function will restore every GRPC Profile Variable to its value when the agent was created and return the
+// previous values
+func (client *Client) ResetAll(ctx context.Context) (map[Variable]int, error) {
+	values, err := client.service().ResetAll(ctx, &empty.Empty{}, client.callOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.sessionMutex.Lock()
+	defer client.sessionMutex.Unlock()
+	client.sessionChanges = nil
+	return variablesFromProto(values), nil
+}
+
+// variablesFromProto will convert the variable values sent by the agent, skipping the variables unknown to the client
+func variablesFromProto(values *proto.VariablesType) map[Variable]int {
+	variables := make(map[proto.ProfileVariable]Variable, len(lookupVariable))
+	for v, pv := range lookupVariable {
+		variables[pv] = v
+	}
+
+	result := make(map[Variable]int, len(values.Values))
+	for _, value := range values.Values {
+		if v, ok := variables[value.Variable]; ok {
+			result[v] = int(value.Value)
+		}
+	}
+	return result
+}
+
+// SetMaxProcs function will set GOMAXPROCS on remote server and return the previous value
+func (client *Client) SetMaxProcs(ctx context.Context, n int) (int, error) {
+	val, err := client.service().SetMaxProcs(ctx, &proto.IntType{Value: int32(n)}, client.callOptions...)
 	if err != nil {
 		return 0, err
 	}
@@ -458,33 +997,240 @@ func (client *Client) Set(ctx context.Context, v Variable, r int) (int, error) {
 
 // GC function will run GC on remote server
 func (client *Client) GC(ctx context.Context) error {
-	_, err := client.client.GC(ctx, &empty.Empty{}, client.callOptions...)
+	_, err := client.service().GC(ctx, &empty.Empty{}, client.callOptions...)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// LookupProfile will run a profile for lookup pprof type
-func (client *Client) LookupProfile(ctx context.Context, t LookupType, writer io.Writer) error {
-	stream, err := client.client.LookupProfile(ctx, &proto.LookupProfileInputType{ProfileType: lookupLookupType[t]}, client.callOptions...)
+// FreeOSMemory function will force a GC on remote server and return as much memory to the operating system as
+// possible
+func (client *Client) FreeOSMemory(ctx context.Context) error {
+	_, err := client.service().FreeOSMemory(ctx, &empty.Empty{}, client.callOptions...)
+	return err
+}
+
+// LookupOption will set an optional parameter of a lookup profile request
+type LookupOption func(*proto.LookupProfileInputType)
+
+// LookupDebug function will create a LookupOption to set the debug level of the profile. The default 0 writes the
+// profile in protobuf format, 1 writes it in legacy text format and 2 writes the goroutine profile as a full stack
+// dump similar to an unrecovered panic
+func LookupDebug(debug int) LookupOption {
+	return func(input *proto.LookupProfileInputType) {
+		input.Debug = int32(debug)
+	}
+}
+
+// LookupSampleType function will create a LookupOption to keep only the sample type named sampleType, e.g.
+// "inuse_space" or "alloc_objects" of a heap profile. The other sample types are dropped by the agent before the
+// profile is sent, so it is smaller and `go tool pprof` shows the selected one by default. It can not be combined
+// with `LookupDebug()`
+func LookupSampleType(sampleType string) LookupOption {
+	return func(input *proto.LookupProfileInputType) {
+		input.SampleType = sampleType
+	}
+}
+
+// LookupGCBefore function will create a LookupOption to run a garbage collection on the agent before a heap profile is
+// written, so the in-use numbers do not include objects which are already unreachable. It is ignored for the other
+// profile types
+func LookupGCBefore() LookupOption {
+	return func(input *proto.LookupProfileInputType) {
+		input.GCBefore = true
+	}
+}
+
+// LookupLabelFilters function will create a LookupOption to keep only the samples with all of the pprof labels of
+// filters, e.g. {"request": "checkout"} for the code run with `pprof.Do(ctx, pprof.Labels("request", "checkout"), ...)`.
+// The other samples are dropped by the agent. It can not be combined with `LookupDebug()`
+func LookupLabelFilters(filters map[string]string) LookupOption {
+	return func(input *proto.LookupProfileInputType) {
+		input.LabelFilters = filters
+	}
+}
+
+// LookupProfile will run a profile for lookup pprof type and stream it into writer. Any `io.Writer` can be used, so the
+// stream can be teed, hashed, compressed or encrypted by composing writers, e.g.
+//
+//	hash := sha256.New()
+//	err := client.LookupProfile(ctx, profile.HeapType, io.MultiWriter(file, hash))
+func (client *Client) LookupProfile(ctx context.Context, t LookupType, writer io.Writer, options ...LookupOption) error {
+	_, err := client.lookupProfile(ctx, t, writer, nil, options)
+	return err
+}
+
+// LookupProfileFiltered will run a profile for lookup pprof type like `LookupProfile()` keeping only the samples with
+// all of the pprof labels of labelFilters, see `LookupLabelFilters()`
+func (client *Client) LookupProfileFiltered(ctx context.Context, t LookupType, labelFilters map[string]string, writer io.Writer, options ...LookupOption) error {
+	return client.LookupProfile(ctx, t, writer, append(options, LookupLabelFilters(labelFilters))...)
+}
+
+// LookupProfileWithMeta will run a profile for lookup pprof type like `LookupProfile()` and return the metadata of the
+// profile sent by the agent. The metadata is nil for agents which do not send it
+func (client *Client) LookupProfileWithMeta(ctx context.Context, t LookupType, writer io.Writer, options ...LookupOption) (*ProfileMeta, error) {
+	_, meta, err := client.WriteLookupProfile(ctx, t, writer, options...)
+	return meta, err
+}
+
+// WriteLookupProfile will run a profile for lookup pprof type like `LookupProfile()` and return the number of bytes
+// written to writer and the metadata of the profile sent by the agent. The metadata is nil for agents which do not
+// send it
+func (client *Client) WriteLookupProfile(ctx context.Context, t LookupType, writer io.Writer, options ...LookupOption) (int64, *ProfileMeta, error) {
+	var meta *ProfileMeta
+	var metaErr error
+	var trailer metadata.MD
+	n, err := client.lookupProfile(ctx, t, writer, func(m *proto.ProfileMeta) {
+		meta, metaErr = metaFromProto(m)
+	}, options, grpc.Trailer(&trailer))
 	if err != nil {
-		return err
+		return n, nil, err
+	}
+	if metaErr == nil && len(trailer.Get(warningMetadataKey)) != 0 {
+		if meta == nil {
+			meta = &ProfileMeta{}
+		}
+		meta.addWarnings(trailer)
+	}
+	return n, meta, metaErr
+}
+
+func (client *Client) lookupProfile(ctx context.Context, t LookupType, writer io.Writer, onMeta func(*proto.ProfileMeta), options []LookupOption, callOptions ...grpc.CallOption) (int64, error) {
+	profileType, ok := lookupLookupType[t]
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownProfileType, t)
+	}
+	input := &proto.LookupProfileInputType{ProfileType: profileType, Compress: client.compress}
+	for _, option := range options {
+		option(input)
+	}
+	if input.SampleType != "" && !client.HasFeature(FeatureSampleType) {
+		return 0, fmt.Errorf("agent does not support feature(s): %s", FeatureSampleType)
 	}
-	return receiveFileChunk(writer, stream)
+	if input.GCBefore && !client.HasFeature(FeatureGCBefore) {
+		return 0, fmt.Errorf("agent does not support feature(s): %s", FeatureGCBefore)
+	}
+	if len(input.LabelFilters) != 0 && !client.HasFeature(FeatureLabelFilter) {
+		return 0, fmt.Errorf("agent does not support feature(s): %s", FeatureLabelFilter)
+	}
+	counter := &countingWriter{writer: writer}
+	err := client.retry.do(ctx, func() error {
+		stream, err := client.service().LookupProfile(ctx, input, append(callOptions, client.callOptions...)...)
+		if err != nil {
+			return err
+		}
+		return receiveFileChunk(counter, stream, input.Compress, onMeta)
+	}, func() bool {
+		return counter.n == 0
+	})
+	return counter.n, err
 }
 
-// NonLookupProfile will run a profile for non lookup pprof type
+// NonLookupProfile will run a profile for non lookup pprof type and stream it into writer. Like `LookupProfile()`,
+// writer can be any `io.Writer`. Unless ctx has a deadline, the call fails with `codes.DeadlineExceeded` if it takes
+// longer than d and the slack set with `DialProfileSlack()`
 func (client *Client) NonLookupProfile(ctx context.Context, t NonLookupType, d time.Duration, writer io.Writer) error {
-	stream, err := client.client.NonLookupProfile(ctx, &proto.NonLookupProfileInputType{ProfileType: lookupNonLookupType[t], Duration: ptypes.DurationProto(d)}, client.callOptions...)
+	_, err := client.nonLookupProfile(ctx, t, d, nil, writer, nil)
+	return err
+}
+
+// NonLookupProfileFiltered will run a profile for non lookup pprof type like `NonLookupProfile()` keeping only the
+// samples with all of the pprof labels of labelFilters, e.g. {"request": "checkout"} for the code run with
+// `pprof.Do(ctx, pprof.Labels("request", "checkout"), ...)`. Traces can not be filtered
+func (client *Client) NonLookupProfileFiltered(ctx context.Context, t NonLookupType, d time.Duration, labelFilters map[string]string, writer io.Writer) error {
+	_, err := client.nonLookupProfile(ctx, t, d, labelFilters, writer, nil)
+	return err
+}
+
+// WriteNonLookupProfile will run a profile for non lookup pprof type like `NonLookupProfile()` and return the number
+// of bytes written to writer and the metadata of the profile sent by the agent. The metadata is nil for agents which
+// do not send it
+func (client *Client) WriteNonLookupProfile(ctx context.Context, t NonLookupType, d time.Duration, writer io.Writer) (int64, *ProfileMeta, error) {
+	var meta *ProfileMeta
+	var metaErr error
+	n, err := client.nonLookupProfile(ctx, t, d, nil, writer, func(m *proto.ProfileMeta) {
+		meta, metaErr = metaFromProto(m)
+	})
+	if err != nil {
+		return n, nil, err
+	}
+	return n, meta, metaErr
+}
+
+func (client *Client) nonLookupProfile(ctx context.Context, t NonLookupType, d time.Duration, labelFilters map[string]string, writer io.Writer, onMeta func(*proto.ProfileMeta)) (int64, error) {
+	if len(labelFilters) != 0 && !client.HasFeature(FeatureLabelFilter) {
+		return 0, fmt.Errorf("agent does not support feature(s): %s", FeatureLabelFilter)
+	}
+	if _, ok := ctx.Deadline(); !ok && client.profileSlack > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d+client.profileSlack)
+		defer cancel()
+	}
+	profileType, ok := lookupNonLookupType[t]
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownProfileType, t)
+	}
+	stream, err := client.service().NonLookupProfile(ctx, &proto.NonLookupProfileInputType{ProfileType: profileType, Duration: ptypes.DurationProto(d), Compress: client.compress, LabelFilters: labelFilters}, client.callOptions...)
+	if err != nil {
+		return 0, err
+	}
+	counter := &countingWriter{writer: writer}
+	err = receiveFileChunk(counter, stream, client.compress, onMeta)
+	return counter.n, err
+}
+
+// ContinuousProfile will run back to back profiles of a non lookup pprof type on remote server, each one lasting
+// interval, and call fn with the sequence number and content of every completed profile. The profiles are gzip
+// compressed on the wire with `WithCompression()`, fn always reads them uncompressed. It runs until ctx is cancelled,
+// which is not reported as an error, or fn returns an error
+func (client *Client) ContinuousProfile(ctx context.Context, t NonLookupType, interval time.Duration, fn func(seq int, r io.Reader) error) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	profileType, ok := lookupNonLookupType[t]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownProfileType, t)
+	}
+	compressed := client.compress
+	stream, err := client.service().ContinuousProfile(streamCtx, &proto.ContinuousProfileInputType{ProfileType: profileType, Interval: ptypes.DurationProto(interval), Compress: compressed}, client.callOptions...)
 	if err != nil {
 		return err
 	}
-	return receiveFileChunk(writer, stream)
+
+	var buf bytes.Buffer
+	for {
+		frame, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return wrapError(err)
+		}
+		buf.Write(frame.Content)
+		if int64(buf.Len()) >= frame.Length {
+			var reader io.Reader = bytes.NewReader(buf.Bytes())
+			if compressed {
+				reader, err = gzip.NewReader(reader)
+				if err != nil {
+					return err
+				}
+			}
+			err = fn(int(frame.Sequence), reader)
+			if err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+	}
 }
 
 // StopNonLookupProfile will stop non lookup profile type (if running)
 func (client *Client) StopNonLookupProfile(ctx context.Context, t NonLookupType) (err error) {
-	_, err = client.client.StopNonLookupProfile(ctx, &proto.NonLookupProfileType{Profile: lookupNonLookupType[t]}, client.callOptions...)
+	profileType, ok := lookupNonLookupType[t]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownProfileType, t)
+	}
+	_, err = client.service().StopNonLookupProfile(ctx, &proto.NonLookupProfileType{Profile: profileType}, client.callOptions...)
 	return
 }
@@ -0,0 +1,907 @@
+package profile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/agent"
+	"github.com/chanchal1987/grpc-profile/proto"
+	pprofile "github.com/google/pprof/profile"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestClient will start an agent created with options on a free local port and return a client connected to it.
+// Both are stopped when the test ends
+func newTestClient(t *testing.T, options ...*agent.ServerOption) (*agent.Agent, *Client) {
+	t.Helper()
+	server, err := agent.NewAgent(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, _, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := Dial(context.Background(), addr.String(), WithInsecure())
+	if err != nil {
+		server.Stop()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Stop()
+		server.Stop()
+	})
+	return server, client
+}
+
+func TestEmptyProfileWarning(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name    string
+		prof    LookupType
+		options []LookupOption
+	}{
+		// No sample has the label, so the filtered profile is empty
+		{"filtered goroutine", GoRoutineType, []LookupOption{LookupLabelFilters(map[string]string{"missing": "label"})}},
+		// Mutex contention is not recorded with a fraction of 0
+		{"mutex", MutexType, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.prof == MutexType {
+				previous := runtime.SetMutexProfileFraction(0)
+				defer runtime.SetMutexProfileFraction(previous)
+				if n := pprof.Lookup("mutex").Count(); n != 0 {
+					t.Skipf("the test process already recorded %d mutex contention events", n)
+				}
+			}
+			_, meta, err := client.WriteLookupProfile(ctx, tc.prof, ioutil.Discard, tc.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if meta == nil || len(meta.WarningStatuses) != 1 {
+				t.Fatalf("warnings of an empty profile: got %+v, want one", meta)
+			}
+			var reason string
+			for _, detail := range meta.WarningStatuses[0].Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					reason = info.Reason
+				}
+			}
+			if reason != agent.ReasonProfileEmpty {
+				t.Errorf("warning reason: got %q, want %q", reason, agent.ReasonProfileEmpty)
+			}
+		})
+	}
+
+	_, meta, err := client.WriteLookupProfile(ctx, GoRoutineType, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta == nil || len(meta.Warnings) != 0 {
+		t.Errorf("warnings of a goroutine profile: got %+v, want none", meta)
+	}
+}
+
+func TestResetSessionChanges(t *testing.T) {
+	previousRate, previousFraction := runtime.MemProfileRate, runtime.SetMutexProfileFraction(7)
+	defer func() {
+		runtime.MemProfileRate = previousRate
+		runtime.SetMutexProfileFraction(previousFraction)
+	}()
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.Set(ctx, MemProfRate, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.ResetSessionChanges(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.MemProfileRate != previousRate {
+		t.Errorf("MemProfileRate after session reset: got %d, want %d", runtime.MemProfileRate, previousRate)
+	}
+	if fraction := runtime.SetMutexProfileFraction(-1); fraction != 7 {
+		t.Errorf("mutex profile fraction set by the application: got %d, want 7", fraction)
+	}
+
+	// A variable reset by the user and changed by the application afterwards is not restored
+	for _, reset := range []func() error{
+		func() error {
+			_, err := client.Reset(ctx, MemProfRate)
+			return err
+		},
+		func() error {
+			_, err := client.ResetAll(ctx)
+			return err
+		},
+	} {
+		_, err = client.Set(ctx, MemProfRate, 1234)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = reset()
+		if err != nil {
+			t.Fatal(err)
+		}
+		runtime.MemProfileRate = 2048
+		err = client.ResetSessionChanges(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if runtime.MemProfileRate != 2048 {
+			t.Errorf("MemProfileRate changed by the application after a reset: got %d, want 2048", runtime.MemProfileRate)
+		}
+	}
+}
+
+func TestDialMaxRecvMsgSize(t *testing.T) {
+	const chunkSize = 8 << 20
+	hash, size, err := binaryHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size <= chunkSize {
+		t.Skipf("the test binary of %d bytes fits in a chunk smaller than %d bytes", size, chunkSize)
+	}
+	server, _ := newTestClient(t, agent.ServerWithChunkSize(chunkSize))
+	ctx := context.Background()
+
+	client, err := Dial(ctx, server.Addr().String(), WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Stop()
+	err = client.BinaryDump(ctx, ioutil.Discard)
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Errorf("chunk above 4 MB with the default receive size: got %v, want %v", err, codes.ResourceExhausted)
+	}
+
+	client, err = Dial(ctx, server.Addr().String(), WithInsecure(), WithDialOption(DialMaxRecvMsgSize(2*chunkSize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Stop()
+	digest := sha256.New()
+	err = client.BinaryDump(ctx, digest)
+	if err != nil {
+		t.Fatalf("chunk above 4 MB with a larger receive size: %v", err)
+	}
+	if got := hex.EncodeToString(digest.Sum(nil)); got != hash {
+		t.Errorf("binary dump hash: got %s, want %s", got, hash)
+	}
+}
+
+// binaryHash will return the SHA-256 hash and the size of the test binary
+func binaryHash() (string, int64, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", 0, err
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", 0, err
+	}
+	digest := sha256.Sum256(content)
+	return hex.EncodeToString(digest[:]), int64(len(content)), nil
+}
+
+func TestDialTimeout(t *testing.T) {
+	// Nothing listens on the port once the listener is closed
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listen.Addr().String()
+	_ = listen.Close()
+
+	start := time.Now()
+	client, err := Dial(context.Background(), addr, WithInsecure(), WithDialOption(DialTimeout(200*time.Millisecond)))
+	if err == nil {
+		_ = client.Stop()
+		t.Fatal("dialing a dead address succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("dialing a dead address took %v with a 200ms timeout", elapsed)
+	}
+}
+
+func TestResetAll(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	changes := map[Variable]int{MemProfRate: 1234, MutexProfileFraction: 3, BlockProfileRate: 1000}
+	startup := make(map[Variable]int, len(changes))
+	for v, value := range changes {
+		current, err := client.Get(ctx, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		startup[v] = current
+		_, err = client.Set(ctx, v, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := client.ResetAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v, want := range startup {
+		got, err := client.Get(ctx, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("variable %d after ResetAll: got %d, want its startup value %d", v, got, want)
+		}
+	}
+}
+
+func TestSetPrevious(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	for _, v := range []Variable{MemProfRate, CPUProfRate, MutexProfileFraction, BlockProfileRate} {
+		current, err := client.Get(ctx, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, value := range []int{current + 7, current + 11} {
+			prev, err := client.Set(ctx, v, value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prev != current {
+				t.Errorf("previous value of variable %d: got %d, want %d", v, prev, current)
+			}
+			current = value
+		}
+		if _, err = client.Reset(ctx, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSetMultiple(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	want := make(map[Variable]int)
+	for _, v := range []Variable{MemProfRate, MutexProfileFraction} {
+		current, err := client.Get(ctx, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want[v] = current
+	}
+
+	prev, err := client.SetMultiple(ctx, map[Variable]int{MemProfRate: 1234, MutexProfileFraction: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _, _ = client.SetMultiple(ctx, want) }()
+	if len(prev) != len(want) {
+		t.Errorf("previous values: got %v, want %v", prev, want)
+	}
+	for v, value := range want {
+		if prev[v] != value {
+			t.Errorf("previous value of variable %d: got %d, want %d", v, prev[v], value)
+		}
+	}
+}
+
+func TestContinuousProfile(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		compress := compress
+		t.Run(fmt.Sprintf("compress=%v", compress), func(t *testing.T) {
+			_, client := newTestClient(t)
+			if compress {
+				if err := client.SetCallOption(WithCompression()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var sequences []int
+			err := client.ContinuousProfile(ctx, CPUType, 100*time.Millisecond, func(seq int, r io.Reader) error {
+				if _, err := pprofile.Parse(r); err != nil {
+					return fmt.Errorf("profile %d: %w", seq, err)
+				}
+				sequences = append(sequences, seq)
+				if len(sequences) == 3 {
+					cancel()
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sequences) != 3 || sequences[0] != 0 || sequences[1] != 1 || sequences[2] != 2 {
+				t.Errorf("sequences of the profiles: got %v, want [0 1 2]", sequences)
+			}
+		})
+	}
+
+	t.Run("max size", func(t *testing.T) {
+		_, client := newTestClient(t, agent.WithMaxProfileSize(16))
+		err := client.ContinuousProfile(context.Background(), CPUType, 100*time.Millisecond, func(int, io.Reader) error {
+			return errors.New("a profile larger than the limit was received")
+		})
+		if !errors.Is(err, ErrProfileTooLarge) {
+			t.Errorf("continuous profile larger than the limit: got %v, want %v", err, ErrProfileTooLarge)
+		}
+	})
+}
+
+// sliceStream is a `fileChunkStream` receiving content in chunks of chunkSize bytes. Its context is ctx, or the
+// background context if ctx is nil
+type sliceStream struct {
+	content   []byte
+	chunkSize int
+	ctx       context.Context
+}
+
+func (stream *sliceStream) Recv() (*proto.FileChunk, error) {
+	if len(stream.content) == 0 {
+		return nil, io.EOF
+	}
+	n := stream.chunkSize
+	if n > len(stream.content) {
+		n = len(stream.content)
+	}
+	chunk := &proto.FileChunk{Content: stream.content[:n]}
+	stream.content = stream.content[n:]
+	return chunk, nil
+}
+
+func (stream *sliceStream) Context() context.Context {
+	if stream.ctx == nil {
+		return context.Background()
+	}
+	return stream.ctx
+}
+
+// BenchmarkReceiveFileChunk will write a 10 MB stream of small chunks to a file, so the writes have to be buffered
+func BenchmarkReceiveFileChunk(b *testing.B) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 10*1024*1024/16)
+	path := filepath.Join(b.TempDir(), "profile")
+	b.SetBytes(int64(len(content)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		file, err := os.Create(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		err = receiveFileChunk(file, &sliceStream{content: content, chunkSize: 1024}, false, nil)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestWatchInfo(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var snapshots int
+	start := time.Now()
+	err := client.WatchInfo(ctx, 100*time.Millisecond, func(info *InfoType) error {
+		if info.NumGoroutine == 0 {
+			return errors.New("snapshot has no goroutines")
+		}
+		snapshots++
+		if snapshots == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshots != 3 {
+		t.Errorf("snapshots: got %d, want 3", snapshots)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("3 snapshots 100ms apart took %v, want at least 200ms", elapsed)
+	}
+}
+
+func TestBinaryHash(t *testing.T) {
+	_, client := newTestClient(t)
+	want, wantSize, err := binaryHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, size, err := client.BinaryHash(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != want || size != wantSize {
+		t.Errorf("binary hash: got %s (%d bytes), want the local hash %s (%d bytes)", hash, size, want, wantSize)
+	}
+}
+
+func TestAgentVersion(t *testing.T) {
+	server, client := newTestClient(t)
+	if client.AgentVersion() == "" {
+		t.Error("agent version is empty after connect")
+	}
+	for _, feature := range []string{FeatureLookupDebug, FeatureCompression, FeatureProfileMeta, FeatureSnapshot, FeatureLabelFilter} {
+		if !client.HasFeature(feature) {
+			t.Errorf("agent does not report feature %s", feature)
+		}
+	}
+
+	_, err := Dial(context.Background(), server.Addr().String(), WithInsecure(), WithDialOption(DialRequireFeatures("time-travel")))
+	if err == nil {
+		t.Error("connect requiring an unknown feature: got no error")
+	}
+}
+
+func TestProfileMeta(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	host, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	start := time.Now()
+	meta, err := client.LookupProfileWithMeta(ctx, HeapType, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta == nil {
+		t.Fatal("heap profile has no metadata")
+	}
+	if meta.ProfileType != "heap" || meta.Hostname != host || meta.GoVersion != runtime.Version() {
+		t.Errorf("heap profile metadata: got %+v, want type heap, host %s and Go %s", meta, host, runtime.Version())
+	}
+	if meta.Time.Before(start.Add(-time.Second)) || meta.Time.After(time.Now()) {
+		t.Errorf("heap profile time: got %v, want about %v", meta.Time, start)
+	}
+	// Only the profile is written
+	if _, err = pprofile.Parse(&buf); err != nil {
+		t.Errorf("heap profile: %v", err)
+	}
+
+	buf.Reset()
+	_, meta, err = client.WriteNonLookupProfile(ctx, CPUType, 100*time.Millisecond, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta == nil || meta.ProfileType != "cpu" || meta.Duration != 100*time.Millisecond {
+		t.Errorf("CPU profile metadata: got %+v, want type cpu lasting 100ms", meta)
+	}
+	if _, err = pprofile.Parse(&buf); err != nil {
+		t.Errorf("CPU profile: %v", err)
+	}
+}
+
+func TestWriteProfileSize(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compress=%t", compress), func(t *testing.T) {
+			_, client := newTestClient(t)
+			if compress {
+				if err := client.SetCallOption(WithCompression()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctx := context.Background()
+			dir := t.TempDir()
+
+			// writeFile will write a profile with write to a file and check the returned size against the file size
+			writeFile := func(name string, write func(io.Writer) (int64, *ProfileMeta, error)) *ProfileMeta {
+				file, err := os.Create(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer file.Close()
+				n, meta, err := write(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				info, err := file.Stat()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n == 0 || n != info.Size() {
+					t.Errorf("bytes written of %s: got %d, want the file size %d", name, n, info.Size())
+				}
+				return meta
+			}
+
+			meta := writeFile("heap.pb.gz", func(writer io.Writer) (int64, *ProfileMeta, error) {
+				return client.WriteLookupProfile(ctx, HeapType, writer)
+			})
+			if meta == nil || meta.ProfileType != "heap" {
+				t.Errorf("heap profile metadata: got %+v, want type heap", meta)
+			}
+			meta = writeFile("goroutine.txt", func(writer io.Writer) (int64, *ProfileMeta, error) {
+				return client.WriteLookupProfile(ctx, GoRoutineType, writer, LookupDebug(2))
+			})
+			if meta == nil || meta.ProfileType != "goroutine" {
+				t.Errorf("goroutine profile metadata: got %+v, want type goroutine", meta)
+			}
+			meta = writeFile("cpu.pb.gz", func(writer io.Writer) (int64, *ProfileMeta, error) {
+				return client.WriteNonLookupProfile(ctx, CPUType, 100*time.Millisecond, writer)
+			})
+			if meta == nil || meta.ProfileType != "cpu" || meta.Duration != 100*time.Millisecond {
+				t.Errorf("CPU profile metadata: got %+v, want type cpu lasting 100ms", meta)
+			}
+		})
+	}
+}
+
+// parkGoroutine will block until stop is closed, so it shows up in goroutine dumps
+//
+//go:noinline
+func parkGoroutine(stop chan struct{}) {
+	<-stop
+}
+
+func TestLookupDebug(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	stop := make(chan struct{})
+	defer close(stop)
+	go parkGoroutine(stop)
+
+	var buf bytes.Buffer
+	err := client.LookupProfile(ctx, GoRoutineType, &buf, LookupDebug(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dump := buf.String()
+	if !strings.HasPrefix(dump, "goroutine ") || strings.Count(dump, "\n\ngoroutine ") == 0 {
+		t.Errorf("goroutine dump with debug 2: got %.200q, want goroutine headers", dump)
+	}
+	if !strings.Contains(dump, ".parkGoroutine(") || !strings.Contains(dump, "[chan receive") {
+		t.Error("goroutine dump with debug 2 has no full stack of the parked goroutine")
+	}
+
+	buf.Reset()
+	err = client.LookupProfile(ctx, HeapType, &buf, LookupDebug(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "heap profile: ") {
+		t.Errorf("heap profile with debug 1: got %.200q, want the text format", buf.String())
+	}
+
+	buf.Reset()
+	err = client.LookupProfile(ctx, GoRoutineType, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pprofile.Parse(&buf); err != nil {
+		t.Errorf("goroutine profile with debug 0: %v", err)
+	}
+}
+
+func TestSetMaxProcs(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	initial := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(initial)
+
+	want := initial + 1
+	prev, err := client.SetMaxProcs(ctx, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != initial || runtime.GOMAXPROCS(0) != want {
+		t.Errorf("set GOMAXPROCS to %d: got previous %d and %d, want %d and %d", want, prev, runtime.GOMAXPROCS(0), initial, want)
+	}
+	info, err := client.GetInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.GOMAXPROCS != want {
+		t.Errorf("GOMAXPROCS of the info: got %d, want %d", info.GOMAXPROCS, want)
+	}
+
+	// Setting the previous value back restores it
+	prev, err = client.SetMaxProcs(ctx, prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != want || runtime.GOMAXPROCS(0) != initial {
+		t.Errorf("restore GOMAXPROCS: got previous %d and %d, want %d and %d", prev, runtime.GOMAXPROCS(0), want, initial)
+	}
+
+	for _, n := range []int{0, -1} {
+		if _, err = client.SetMaxProcs(ctx, n); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("set GOMAXPROCS to %d: got %v, want %v", n, err, codes.InvalidArgument)
+		}
+	}
+	if runtime.GOMAXPROCS(0) != initial {
+		t.Errorf("GOMAXPROCS after invalid values: got %d, want %d", runtime.GOMAXPROCS(0), initial)
+	}
+}
+
+func TestWaitForInfo(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	info, err := client.GetInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const leaked = 50
+	threshold := info.NumGoroutine + leaked/2
+
+	stop := make(chan struct{})
+	leakGoroutines(leaked, stop)
+	stopped := time.Now().Add(200 * time.Millisecond)
+	time.AfterFunc(200*time.Millisecond, func() { close(stop) })
+
+	var polls int
+	info, err = client.WaitForInfo(ctx, func(info *InfoType) bool {
+		polls++
+		return info.NumGoroutine < threshold
+	}, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Now().Before(stopped) || info.NumGoroutine >= threshold {
+		t.Errorf("wait for the goroutines to exit: got %d goroutines, want fewer than %d after they stopped", info.NumGoroutine, threshold)
+	}
+	if polls < 2 {
+		t.Errorf("polls of the info: got %d, want several", polls)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = client.WaitForInfo(ctx, func(*InfoType) bool { return false }, 20*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) && status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("wait for a condition which never holds: got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestReceiveFileChunkCompressed(t *testing.T) {
+	var content bytes.Buffer
+	for i := 0; content.Len() < 4*1024*1024; i++ {
+		fmt.Fprintf(&content, "goroutine %d [chan receive]:\nmain.worker(%#x)\n\n", i, i*i)
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(content.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		stream     []byte
+		compressed bool
+	}{
+		{"uncompressed", content.Bytes(), false},
+		{"compressed", compressed.Bytes(), true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := receiveFileChunk(&buf, &sliceStream{content: tc.stream, chunkSize: 32 * 1024}, tc.compressed, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), content.Bytes()) {
+				t.Errorf("received %d bytes, want the %d bytes sent", buf.Len(), content.Len())
+			}
+		})
+	}
+
+	err := receiveFileChunk(ioutil.Discard, &sliceStream{content: compressed.Bytes()[:compressed.Len()/2], chunkSize: 1024}, true, nil)
+	if err == nil {
+		t.Error("truncated compressed stream: got no error")
+	}
+
+	// A large goroutine dump is sent compressed and written as it is
+	_, client := newTestClient(t)
+	stop := make(chan struct{})
+	defer close(stop)
+	leakGoroutines(1000, stop)
+	if err = client.SetCallOption(WithCompression()); err != nil {
+		t.Fatal(err)
+	}
+	var received int64
+	ctx := WithProgress(context.Background(), func(bytesReceived int64) { received = bytesReceived })
+	var buf bytes.Buffer
+	n, _, err := client.WriteLookupProfile(ctx, GoRoutineType, &buf, LookupDebug(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(buf.String(), "goroutine ") < 1000 {
+		t.Errorf("compressed goroutine dump: got %d bytes without the leaked goroutines", buf.Len())
+	}
+	if received == 0 || received >= n {
+		t.Errorf("bytes on the wire: got %d, want fewer than the %d bytes written", received, n)
+	}
+}
+
+// failingWriter fails every write with err
+type failingWriter struct {
+	err error
+}
+
+func (writer failingWriter) Write([]byte) (int, error) {
+	return 0, writer.err
+}
+
+func TestStreamToMultiWriter(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	for name, collect := range map[string]func(io.Writer) error{
+		"heap": func(writer io.Writer) error {
+			return client.LookupProfile(ctx, HeapType, writer)
+		},
+		"cpu": func(writer io.Writer) error {
+			return client.NonLookupProfile(ctx, CPUType, 50*time.Millisecond, writer)
+		},
+		"binary": func(writer io.Writer) error {
+			return client.BinaryDump(ctx, writer)
+		},
+	} {
+		var first, second bytes.Buffer
+		if err := collect(io.MultiWriter(&first, &second)); err != nil {
+			t.Fatal(err)
+		}
+		if first.Len() == 0 || !bytes.Equal(first.Bytes(), second.Bytes()) {
+			t.Errorf("%s stream: got %d and %d bytes, want the same bytes in both writers", name, first.Len(), second.Len())
+		}
+	}
+
+	writeErr := errors.New("write failed")
+	if err := client.LookupProfile(ctx, HeapType, failingWriter{err: writeErr}); !errors.Is(err, writeErr) {
+		t.Errorf("stream into a failing writer: got %v, want %v", err, writeErr)
+	}
+}
+
+func TestDialKeepalive(t *testing.T) {
+	var client Client
+	err := client.SetDialOption(DialKeepalive(time.Minute, 20*time.Second, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client.dialOptions) != 1 {
+		t.Fatalf("dial options after DialKeepalive: got %d, want 1", len(client.dialOptions))
+	}
+
+	// An agent allowing the pings accepts a client sending them
+	server, _ := newTestClient(t, agent.ServerKeepaliveEnforcement(time.Minute, true))
+	keepalive, err := Dial(context.Background(), server.Addr().String(), WithInsecure(), WithDialOption(DialKeepalive(time.Minute, 20*time.Second, true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keepalive.Stop()
+	if _, err = keepalive.Ping(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	_, client := newTestClient(t)
+
+	latency, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latency < 0 || latency > 5*time.Second {
+		t.Errorf("ping latency to a local agent: got %v", latency)
+	}
+
+	_ = client.Stop()
+	if _, err = client.Ping(context.Background()); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("ping after stop: got %v, want %v", err, ErrNotConnected)
+	}
+}
+
+func TestDialOptionError(t *testing.T) {
+	server, _ := newTestClient(t)
+	ctx := context.Background()
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+
+	if _, err := Dial(ctx, server.Addr().String(), WithTLS(missing)); err == nil {
+		t.Error("Dial with a missing certificate: got no error")
+	}
+	if _, err := NewClient(ctx, server.Addr().String(), DialAuthTypeTLS(missing)); err == nil {
+		t.Error("NewClient with a missing certificate: got no error")
+	}
+
+	client, err := Dial(ctx, server.Addr().String(), WithInsecure(), WithToken("token"), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = client.Stop()
+}
+
+// retained keeps the objects allocated by `allocateRetained()` reachable
+var retained [][]byte
+
+//go:noinline
+func allocateRetained(n int) {
+	for i := 0; i < n; i++ {
+		retained = append(retained, make([]byte, 64))
+	}
+}
+
+func TestLookupGCBefore(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	// Every allocation is sampled and only the GCs of the test update the heap profile
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+
+	// inUse will return the number of in-use objects allocated by allocateRetained in the heap profile
+	inUse := func(options ...LookupOption) int64 {
+		t.Helper()
+		var buf bytes.Buffer
+		err := client.LookupProfile(ctx, HeapType, &buf, append(options, LookupSampleType("inuse_objects"))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := pprofile.Parse(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var n int64
+		for _, sample := range p.Sample {
+			for _, location := range sample.Location {
+				for _, line := range location.Line {
+					if strings.HasSuffix(line.Function.Name, ".allocateRetained") {
+						n += sample.Value[0]
+					}
+				}
+			}
+		}
+		return n
+	}
+
+	const objects = 10000
+	allocateRetained(objects)
+	runtime.GC()
+	retained = nil
+
+	// The objects are unreachable now, but the profile is as of the last GC
+	before := inUse()
+	if before < objects {
+		t.Fatalf("in-use objects without a GC: got %d, want at least %d", before, objects)
+	}
+	if after := inUse(LookupGCBefore()); after >= before {
+		t.Errorf("in-use objects after a GC: got %d, want fewer than %d", after, before)
+	}
+}
@@ -1,35 +1,78 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"os"
 
+	profile "github.com/chanchal1987/grpc-profile"
 	"github.com/spf13/cobra"
 )
 
+var (
+	binDumpVerify   bool
+	binDumpProgress bool
+)
+
 func init() {
 	rootCmd.AddCommand(binDumpCmd)
+
+	binDumpCmd.Flags().BoolVar(&binDumpVerify, "verify", false, "Verify the dumped binary against the SHA-256 hash reported by the agent")
+	binDumpCmd.Flags().BoolVar(&binDumpProgress, "progress", false, "Show the number of bytes received on stderr")
 }
 
 var (
 	binDumpCmd = &cobra.Command{
-		Use:     "bin-dump <file-name>",
+		Use:     "bin-dump <file-name|->",
 		Short:   "Get a dumo of the binary file where the agent is running",
-		Long:    `Get a dumo of the binary file where the agent is running`,
+		Long:    `Get a dumo of the binary file where the agent is running. Use "-" as file name to write to stdout`,
 		PreRunE: connect,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			if len(args) != 1 {
 				return errInvalidArguments
 			}
-			var file *os.File
+			var file io.WriteCloser
 
-			file, err = os.Create(args[0])
+			file, err = createOutput(args[0])
 			if err != nil {
 				return
 			}
 			defer func() {
-				err = file.Close()
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
 			}()
-			return client.BinaryDump(cmd.Context(), file)
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			if binDumpProgress {
+				ctx = profile.WithProgress(ctx, func(bytesReceived int64) {
+					fmt.Fprintf(os.Stderr, "\rReceived %d bytes", bytesReceived)
+				})
+			}
+
+			hash := sha256.New()
+			writer := io.Writer(file)
+			if binDumpVerify {
+				writer = io.MultiWriter(file, hash)
+			}
+			err = client.BinaryDump(ctx, writer)
+			if binDumpProgress {
+				fmt.Fprintln(os.Stderr)
+			}
+			if err != nil || !binDumpVerify {
+				return
+			}
+			expected, size, err := client.BinaryHash(ctx)
+			if err != nil {
+				return
+			}
+			if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+				return fmt.Errorf("verification failed: got SHA-256 %s, agent reported %s (%d bytes)", actual, expected, size)
+			}
+			fmt.Fprintln(os.Stderr, "Verified SHA256:", expected)
+			return
 		},
 	}
 )
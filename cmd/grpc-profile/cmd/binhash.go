@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(binHashCmd)
+}
+
+var (
+	binHashCmd = &cobra.Command{
+		Use:     "bin-hash",
+		Short:   "Get the SHA-256 hash of the binary file where the agent is running",
+		Long:    `Get the SHA-256 hash and the size of the binary file where the agent is running`,
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return errInvalidArguments
+			}
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			hash, size, err := client.BinaryHash(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Println("SHA256:", hash)
+			fmt.Println("Size:  ", size)
+			return nil
+		},
+	}
+)
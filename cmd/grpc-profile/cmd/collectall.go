@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(collectAllCmd)
+	collectAllCmd.Flags().DurationVar(&collectAllCPUDuration, "cpu-duration", 30*time.Second, "Duration of the CPU profile. 0 skips it")
+	collectAllCmd.Flags().DurationVar(&collectAllTraceDuration, "trace-duration", 5*time.Second, "Duration of the trace. 0 skips it")
+}
+
+var (
+	collectAllCPUDuration   time.Duration
+	collectAllTraceDuration time.Duration
+
+	collectAllCmd = &cobra.Command{
+		Use:     "collect-all <dir>",
+		Short:   "Collect every profile type into a directory",
+		Long:    `Collect the heap, goroutine, block, mutex, threadcreate, allocs and CPU profiles and a trace one after the other into a directory. A failing profile type does not stop the others, the failures are reported at the end`,
+		Example: applName + " collect-all ./profiles\n" + applName + " collect-all ./profiles --cpu-duration 10s --trace-duration 0",
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errInvalidArguments
+			}
+			ctx, cancel := withTimeout(cmd.Context(), collectAllCPUDuration+collectAllTraceDuration)
+			defer cancel()
+			err := client.CollectAll(ctx, args[0], collectAllCPUDuration, collectAllTraceDuration)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Profiles collected into", args[0])
+			return nil
+		},
+	}
+)
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+}
+
+var (
+	compareCmd = &cobra.Command{
+		Use:     "compare <address> <address>",
+		Short:   "Compare two agents",
+		Long:    `Compare the configuration and runtime of the agents running on two servers. Fields which differ are marked with '*'`,
+		Example: applName + " compare host-a:8080 host-b:8080",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errInvalidArguments
+			}
+
+			var reports [2]map[string]string
+			for i, address := range args {
+				report, err := compareServer(cmd.Context(), address)
+				if err != nil {
+					return fmt.Errorf("%s: %w", address, err)
+				}
+				reports[i] = report
+			}
+			return writeComparison(os.Stdout, [2]string{args[0], args[1]}, reports)
+		},
+	}
+)
+
+// compareServer will collect the report of the agent at address within the '--timeout'
+func compareServer(parent context.Context, address string) (map[string]string, error) {
+	ctx, cancel := withTimeout(parent, 0)
+	defer cancel()
+	return compareReport(ctx, address)
+}
+
+// writeComparison will write the fields of both reports to w, marking the ones which differ with '*'. Environment
+// variables are only written if they differ
+func writeComparison(w io.Writer, names [2]string, reports [2]map[string]string) error {
+	keys := make(map[string]bool)
+	for _, report := range reports {
+		for key := range report {
+			keys[key] = true
+		}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, " \tFIELD\t%s\t%s\n", names[0], names[1])
+	for _, key := range sortedKeys {
+		a, b := reports[0][key], reports[1][key]
+		marker := " "
+		if a != b {
+			marker = "*"
+		} else if strings.HasPrefix(key, "Environ.") {
+			// Only report environment deltas
+			continue
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", marker, key, a, b)
+	}
+	return writer.Flush()
+}
+
+// compareReport will collect the fields of an agent to compare
+func compareReport(ctx context.Context, address string) (map[string]string, error) {
+	agent, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = agent.Stop()
+	}()
+
+	info, err := agent.GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := map[string]string{
+		"Version":              info.Version,
+		"GOOS":                 info.GOOS,
+		"GOARCH":               info.GOARCH,
+		"GOMAXPROCS":           strconv.Itoa(info.GOMAXPROCS),
+		"NumCPU":               strconv.Itoa(info.NumCPU),
+		"MemProfileRate":       strconv.Itoa(info.MemProfileRate),
+		"Executable":           info.ProcessStats.Executable,
+		"ExecutableStat.Size":  strconv.FormatInt(info.ProcessStats.ExecutableStat.Size, 10),
+		"ExecutableStat.Mode":  os.FileMode(info.ProcessStats.ExecutableStat.Mode).String(),
+		"ExecutableStat.MTime": info.ProcessStats.ExecutableStat.ModeTime.String(),
+		"Hostname":             info.ProcessStats.Hostname,
+		"UID":                  info.ProcessStats.UID.Name,
+		"GID":                  info.ProcessStats.GID.Name,
+		"PageSize":             strconv.Itoa(info.ProcessStats.PageSize),
+		"WD":                   info.ProcessStats.WD,
+	}
+	for _, env := range info.ProcessStats.Environ {
+		kv := strings.SplitN(env, "=", 2)
+		if len(kv) == 2 {
+			report["Environ."+kv[0]] = kv[1]
+		}
+	}
+	for name, variable := range setList {
+		value, err := agent.Get(ctx, variable)
+		if err != nil {
+			return nil, err
+		}
+		report["Variable."+name] = strconv.Itoa(value)
+	}
+	return report, nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/chanchal1987/grpc-profile/agent"
+)
+
+func TestCompareGOMAXPROCS(t *testing.T) {
+	insecure = true
+	defer func() { insecure = false }()
+
+	var names [2]string
+	for i := range names {
+		server, err := agent.NewAgent()
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr, _, err := server.Start("127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer server.Stop()
+		names[i] = addr.String()
+	}
+
+	// GOMAXPROCS is global to the process, so it is changed between the reports of the agents
+	previous := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(previous)
+	var reports [2]map[string]string
+	for i, name := range names {
+		runtime.GOMAXPROCS(previous + i)
+		report, err := compareServer(context.Background(), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reports[i] = report
+	}
+
+	var out bytes.Buffer
+	err := writeComparison(&out, names, reports)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`(?m)^\*\s+GOMAXPROCS\s`).Match(out.Bytes()) {
+		t.Errorf("GOMAXPROCS is not marked as different:\n%s", out.String())
+	}
+	if regexp.MustCompile(`(?m)^\*\s+GOOS\s`).Match(out.Bytes()) {
+		t.Errorf("GOOS is marked as different:\n%s", out.String())
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	profile "github.com/chanchal1987/grpc-profile"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	downloadSampleType string
+	downloadLabels     map[string]string
+)
+
+func init() {
+	rootCmd.AddCommand(downloadCmd)
+
+	downloadCmd.Flags().StringVar(&downloadSampleType, "sample-type", "", "Sample type the lookup profiles were kept with")
+	downloadCmd.Flags().StringToStringVar(&downloadLabels, "label", nil, "Pprof label the profiles were kept with, e.g. request=checkout. Can be repeated")
+	downloadCmd.Flags().StringVar(&keepDir, "keep-dir", defaultKeepDir, "Directory of the profiles kept with 'profile --keep'")
+}
+
+var (
+	downloadCmd = &cobra.Command{
+		Use:     "download <profile-type> <file-name|->",
+		Short:   "Download the merge of the profiles kept with 'profile --keep'",
+		Long:    `Download the merge of the profiles kept with 'profile --keep' for the server with the same type, '--sample-type' and '--label's without running a new profile. Use "-" as file name to write to stdout`,
+		Example: applName + " download heap heap.pb.gz\n" + applName + " download cpu cpu.pb.gz",
+		PreRunE: connect,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return profileCmd.ValidArgsFunction(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveDefault
+		},
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 2 {
+				return errInvalidArguments
+			}
+			lookup, isLookup := lookupTypes[args[0]]
+			nonLookup, isNonLookup := nonLookupTypes[args[0]]
+			if !isLookup && !isNonLookup {
+				return errInvalidArguments
+			}
+			var file io.WriteCloser
+			file, err = createOutput(args[1])
+			if err != nil {
+				return
+			}
+			defer func() {
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
+			}()
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			if isLookup {
+				options := []profile.LookupOption{profile.LookupLabelFilters(downloadLabels)}
+				if downloadSampleType != "" {
+					options = append(options, profile.LookupSampleType(downloadSampleType))
+				}
+				err = client.DownloadLookupProfile(ctx, lookup, file, options...)
+			} else {
+				err = client.DownloadNonLookupProfile(ctx, nonLookup, downloadLabels, file)
+			}
+			if errors.Is(err, profile.ErrNothingKept) {
+				return fmt.Errorf("no %s profile is kept for %s, collect one with 'profile %s --keep': %w", args[0], viper.GetString("server"), args[0], err)
+			}
+			return
+		},
+	}
+)
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	profile "github.com/chanchal1987/grpc-profile"
+)
+
+func TestDownload(t *testing.T) {
+	addr := startCLIAgent(t)
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept")
+	merged := filepath.Join(dir, "merged.pb.gz")
+	downloaded := filepath.Join(dir, "downloaded.pb.gz")
+
+	err := runCLI(t, addr, "download", "heap", downloaded, "--keep-dir", kept)
+	if !errors.Is(err, profile.ErrNothingKept) {
+		t.Fatalf("download before keeping: got %v, want %v", err, profile.ErrNothingKept)
+	}
+	for i := 0; i < 2; i++ {
+		err = runCLI(t, addr, "profile", "heap", merged, "--keep", "--keep-dir", kept)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The memory alias names the same kept profiles as heap
+	err = runCLI(t, addr, "download", "memory", downloaded, "--keep-dir", kept)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sampleTotal(t, downloaded), sampleTotal(t, merged); got != want {
+		t.Errorf("total of the downloaded profile: got %d, want the total of the last merge %d", got, want)
+	}
+
+	err = runCLI(t, addr, "download", "heap", downloaded, "--keep-dir", kept, "--sample-type", "inuse_space")
+	if !errors.Is(err, profile.ErrNothingKept) {
+		t.Errorf("download of another sample type: got %v, want %v", err, profile.ErrNothingKept)
+	}
+}
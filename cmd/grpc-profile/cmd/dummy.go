@@ -5,17 +5,23 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/chanchal1987/grpc-profile/agent"
+	"github.com/chanchal1987/grpc-profile/proto"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 )
 
 func init() {
 	rootCmd.AddCommand(dummyCmd)
+	dummyCmd.Flags().StringVar(&dummyLoad, "load", "cpu", "Synthetic workload matching the profile type to validate. One of cpu, alloc, mutex or block")
 }
 
+var dummyLoad string
+
 var dummyCmd = &cobra.Command{
 	Use:       "dummy-agent [server-address [duration]]",
 	Short:     "Start a dummy agent",
@@ -39,7 +45,7 @@ var dummyCmd = &cobra.Command{
 			return err
 		}
 
-		tcpAddr, err := server.Start(addr)
+		tcpAddr, serveErr, err := server.Start(addr)
 		if err != nil {
 			return err
 		}
@@ -74,21 +80,99 @@ var dummyCmd = &cobra.Command{
 			}
 		}
 
-		// Add some load to agent
-		for i := 0; i < runtime.NumCPU(); i++ {
-			go func() {
-				for {
+		err = startDummyLoad(ctx, server, dummyLoad)
+		if err != nil {
+			calcelFunc()
+			return err
+		}
+		select {
+		case <-ctx.Done():
+		case err = <-serveErr:
+		}
+		calcelFunc()
+		return err
+	},
+}
+
+// startDummyLoad will start the synthetic workload kind on every CPU until ctx is done. The mutex and block loads
+// enable the matching profile through the agent, so their profiles have samples
+func startDummyLoad(ctx context.Context, server *agent.Agent, kind string) error {
+	var work func()
+	switch kind {
+	case "cpu":
+		work = func() {
+			deadline := time.Now().Add(time.Millisecond)
+			for time.Now().Before(deadline) {
+			}
+		}
+	case "alloc":
+		work = func() {
+			for i := 0; i < 1000; i++ {
+				dummySink.Store(make([]byte, 1024))
+			}
+		}
+	case "mutex":
+		if _, err := server.Set(ctx, &proto.SetProfileInputType{Variable: proto.ProfileVariable_MutexProfileFraction, Rate: 1}); err != nil {
+			return err
+		}
+		var mutex sync.Mutex
+		work = func() {
+			mutex.Lock()
+			time.Sleep(100 * time.Microsecond)
+			mutex.Unlock()
+		}
+	case "block":
+		if _, err := server.Set(ctx, &proto.SetProfileInputType{Variable: proto.ProfileVariable_BlockProfileRate, Rate: 1}); err != nil {
+			return err
+		}
+		ch := make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
 					select {
-					case <-ctx.Done():
-						return
+					case ch <- struct{}{}:
 					default:
-						continue
 					}
 				}
-			}()
+			}
+		}()
+		work = func() {
+			select {
+			case <-ch:
+			case <-ctx.Done():
+			}
 		}
-		<-ctx.Done()
-		calcelFunc()
-		return nil
-	},
+	default:
+		return fmt.Errorf("unknown load %q", kind)
+	}
+
+	// Run the load annotated with trace regions so that collected traces show user regions. At least two workers are
+	// needed for the mutex load to contend
+	workers := runtime.NumCPU()
+	if workers < 2 {
+		workers = 2
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			taskCtx, endTask := agent.StartTraceTask(ctx, "dummy-load")
+			defer endTask()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					agent.WithTraceRegion(taskCtx, kind, work)
+				}
+			}
+		}()
+	}
+	return nil
 }
+
+// dummySink keeps the allocations of the alloc load from being optimized away, the workers store into it concurrently
+var dummySink atomic.Value
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	profile "github.com/chanchal1987/grpc-profile"
+	"github.com/chanchal1987/grpc-profile/agent"
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestDummyLoad(t *testing.T) {
+	for _, tc := range []struct {
+		load    string
+		profile profile.LookupType
+	}{
+		{"mutex", profile.MutexType},
+		{"block", profile.BlockType},
+	} {
+		t.Run(tc.load, func(t *testing.T) {
+			server, err := agent.NewAgent()
+			if err != nil {
+				t.Fatal(err)
+			}
+			addr, _, err := server.Start("127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer server.Stop()
+			client, err := profile.Dial(context.Background(), addr.String(), profile.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Stop()
+			defer func() {
+				_, _ = client.ResetAll(context.Background())
+			}()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			err = startDummyLoad(ctx, server, tc.load)
+			if err != nil {
+				cancel()
+				t.Fatal(err)
+			}
+			time.Sleep(200 * time.Millisecond)
+			cancel()
+
+			var buf bytes.Buffer
+			err = client.LookupProfile(context.Background(), tc.profile, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := pprofile.Parse(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p.Sample) == 0 {
+				t.Errorf("%s profile of the %s load has no samples", tc.profile, tc.load)
+			}
+		})
+	}
+}
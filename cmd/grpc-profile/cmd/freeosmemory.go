@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(freeOSMemoryCmd)
+}
+
+var (
+	freeOSMemoryCmd = &cobra.Command{
+		Use:     "free-os-memory",
+		Short:   "Return freed memory to the OS on remote server",
+		Long:    `Run forced GC on remote server where the agent is running and return as much memory to the operating system as possible`,
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return errInvalidArguments
+			}
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			return client.FreeOSMemory(ctx)
+		},
+	}
+)
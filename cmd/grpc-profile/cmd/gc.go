@@ -18,7 +18,9 @@ var (
 			if len(args) != 0 {
 				return errInvalidArguments
 			}
-			return client.GC(cmd.Context())
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			return client.GC(ctx)
 		},
 	}
 )
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(getCmd)
+}
+
+var (
+	getCmd = &cobra.Command{
+		Use:               "get <variable>",
+		Short:             "Get variable from agent",
+		Long:              `Get the current value of a variable in the agent where this server is connected`,
+		PreRunE:           connect,
+		ValidArgsFunction: completeVariable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errInvalidArguments
+			}
+			val, ok := setList[args[0]]
+			if !ok {
+				return errors.New("unknown variable")
+			}
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			rt, err := client.Get(ctx, val)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Value of", args[0], "is", rt)
+			return nil
+		},
+	}
+)
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(goroutineDeltaCmd)
+	goroutineDeltaCmd.Flags().StringVar(&goroutineDeltaBefore, "before", "", "Write a goroutine profile taken at the start of the interval to this file")
+	goroutineDeltaCmd.Flags().StringVar(&goroutineDeltaAfter, "after", "", "Write a goroutine profile taken at the end of the interval to this file")
+}
+
+var (
+	goroutineDeltaBefore string
+	goroutineDeltaAfter  string
+
+	goroutineDeltaCmd = &cobra.Command{
+		Use:     "goroutine-delta <duration>",
+		Short:   "Show the change in the number of goroutines",
+		Long:    `Read the number of goroutines of the agent, wait for the duration, read it again and print the change. Useful to catch goroutine leaks during a load test`,
+		Example: applName + " goroutine-delta 30s\n" + applName + " goroutine-delta 30s --before before.pb.gz --after after.pb.gz",
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 1 {
+				return errInvalidArguments
+			}
+			dur, err := time.ParseDuration(args[0])
+			if err != nil {
+				return err
+			}
+
+			var beforeWriter, afterWriter io.Writer
+			if goroutineDeltaBefore != "" {
+				file, err := createOutput(goroutineDeltaBefore)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if cerr := file.Close(); err == nil {
+						err = cerr
+					}
+				}()
+				beforeWriter = file
+			}
+			if goroutineDeltaAfter != "" {
+				file, err := createOutput(goroutineDeltaAfter)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if cerr := file.Close(); err == nil {
+						err = cerr
+					}
+				}()
+				afterWriter = file
+			}
+
+			ctx, cancel := withTimeout(cmd.Context(), dur)
+			defer cancel()
+			before, after, err := client.GoroutineDeltaWithProfiles(ctx, dur, beforeWriter, afterWriter)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Goroutines: %d -> %d (%+d)\n", before, after, after-before)
+			return nil
+		},
+	}
+)
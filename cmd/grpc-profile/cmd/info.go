@@ -3,12 +3,20 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"text/tabwriter"
 
+	profile "github.com/chanchal1987/grpc-profile"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
+var infoFormat string
+
 func init() {
 	rootCmd.AddCommand(infoCmd)
+
+	infoCmd.Flags().StringVar(&infoFormat, "format", "json", "Output format. One of json, yaml or table")
 }
 
 var (
@@ -21,19 +29,47 @@ var (
 			if len(args) != 0 {
 				return errInvalidArguments
 			}
-			info, err := client.GetInfo(cmd.Context())
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			info, err := client.GetInfo(ctx)
 			if err != nil {
 				return err
 			}
 
-			out, err := json.MarshalIndent(info, "", "  ")
-			if err != nil {
-				return err
+			switch infoFormat {
+			case "json":
+				out, err := json.MarshalIndent(info, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println("Information:")
+				fmt.Println(string(out))
+			case "yaml":
+				out, err := yaml.Marshal(info)
+				if err != nil {
+					return err
+				}
+				fmt.Print(string(out))
+			case "table":
+				return printInfoTable(info)
+			default:
+				return fmt.Errorf("unknown format %q", infoFormat)
 			}
-
-			fmt.Println("Information:")
-			fmt.Println(string(out))
 			return nil
 		},
 	}
 )
+
+func printInfoTable(info *profile.InfoType) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Go version\t%s\n", info.Version)
+	fmt.Fprintf(w, "OS/Arch\t%s/%s\n", info.GOOS, info.GOARCH)
+	fmt.Fprintf(w, "Hostname\t%s\n", info.ProcessStats.Hostname)
+	fmt.Fprintf(w, "PID\t%d\n", info.ProcessStats.PID)
+	fmt.Fprintf(w, "GOMAXPROCS\t%d\n", info.GOMAXPROCS)
+	fmt.Fprintf(w, "Goroutines\t%d\n", info.NumGoroutine)
+	fmt.Fprintf(w, "Heap alloc\t%d\n", info.MemStats.HeapAlloc)
+	fmt.Fprintf(w, "Num GC\t%d\n", info.MemStats.NumGC)
+	fmt.Fprintf(w, "Active RPCs\t%d\n", info.ActiveRPCs)
+	return w.Flush()
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	profile "github.com/chanchal1987/grpc-profile"
+	"gopkg.in/yaml.v2"
+)
+
+// captureStdout will return what fn writes to stdout
+func captureStdout(t *testing.T, fn func() error) ([]byte, error) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	out := make(chan []byte)
+	go func() {
+		content, _ := ioutil.ReadAll(reader)
+		out <- content
+	}()
+	err = fn()
+	os.Stdout = stdout
+	_ = writer.Close()
+	return <-out, err
+}
+
+func TestInfoFormat(t *testing.T) {
+	addr := startCLIAgent(t)
+	defer func() { infoFormat = "json" }()
+
+	out, err := captureStdout(t, func() error { return runCLI(t, addr, "info") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info profile.InfoType
+	if err = json.Unmarshal([]byte(strings.TrimPrefix(string(out), "Information:\n")), &info); err != nil {
+		t.Fatalf("json output: %v\n%s", err, out)
+	}
+	if info.NumGoroutine == 0 || info.ProcessStats.PID != os.Getpid() {
+		t.Errorf("json output: got %d goroutines and PID %d, want goroutines and PID %d", info.NumGoroutine, info.ProcessStats.PID, os.Getpid())
+	}
+
+	out, err = captureStdout(t, func() error { return runCLI(t, addr, "info", "--format", "yaml") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err = yaml.Unmarshal(out, &fields); err != nil {
+		t.Fatalf("yaml output: %v\n%s", err, out)
+	}
+	for _, key := range []string{"goos", "numgoroutine", "memstats", "processstats"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("yaml output has no key %s:\n%s", key, out)
+		}
+	}
+
+	out, err = captureStdout(t, func() error { return runCLI(t, addr, "info", "--format", "table") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	for i, label := range []string{"Go version", "OS/Arch", "Hostname", "PID", "GOMAXPROCS", "Goroutines", "Heap alloc", "Num GC", "Active RPCs"} {
+		if i >= len(lines) || !strings.HasPrefix(lines[i], label+"  ") {
+			t.Errorf("table output line %d: want %q and its value, got:\n%s", i, label, out)
+			break
+		}
+	}
+
+	if err = runCLI(t, addr, "info", "--format", "xml"); err == nil {
+		t.Error("unknown format: got no error")
+	}
+}
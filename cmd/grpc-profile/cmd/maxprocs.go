@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(maxProcsCmd)
+}
+
+var (
+	maxProcsCmd = &cobra.Command{
+		Use:     "maxprocs <n>",
+		Short:   "Set GOMAXPROCS in agent",
+		Long:    `Set GOMAXPROCS in the agent where this server is connected`,
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errInvalidArguments
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			prev, err := client.SetMaxProcs(ctx, n)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Changed value of GOMAXPROCS from", prev, "to", n)
+			return nil
+		},
+	}
+)
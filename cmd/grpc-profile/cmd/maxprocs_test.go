@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestMaxProcs(t *testing.T) {
+	initial := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(initial)
+	addr := startCLIAgent(t)
+
+	n := initial + 1
+	out, err := captureStdout(t, func() error { return runCLI(t, addr, "maxprocs", fmt.Sprint(n)) })
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	if want := fmt.Sprintf("Changed value of GOMAXPROCS from %d to %d\n", initial, n); string(out) != want {
+		t.Errorf("maxprocs output: got %q, want %q", out, want)
+	}
+	if runtime.GOMAXPROCS(0) != n {
+		t.Errorf("GOMAXPROCS after maxprocs: got %d, want %d", runtime.GOMAXPROCS(0), n)
+	}
+
+	for _, args := range [][]string{{"maxprocs", "0"}, {"maxprocs", "many"}, {"maxprocs"}} {
+		if _, err = captureStdout(t, func() error { return runCLI(t, addr, args...) }); err == nil {
+			t.Errorf("%v: got no error", args)
+		}
+	}
+	if runtime.GOMAXPROCS(0) != n {
+		t.Errorf("GOMAXPROCS after invalid values: got %d, want %d", runtime.GOMAXPROCS(0), n)
+	}
+}
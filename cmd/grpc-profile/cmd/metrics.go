@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	profile "github.com/chanchal1987/grpc-profile"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(metricsCmd)
+}
+
+var (
+	metricsCmd = &cobra.Command{
+		Use:     "metrics [prefix...]",
+		Short:   "Get runtime metrics of the server",
+		Long:    `Get the runtime/metrics samples of the agent, optionally only the ones whose name starts with one of the prefixes. Histograms are summarised by their sample count`,
+		Example: applName + " metrics /gc/ /sched/",
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			runtimeMetrics, err := client.GetRuntimeMetrics(ctx)
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(runtimeMetrics))
+			for name := range runtimeMetrics {
+				if len(args) == 0 || hasAnyPrefix(name, args) {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, name := range names {
+				metric := runtimeMetrics[name]
+				switch metric.Kind {
+				case profile.MetricKindUint64:
+					fmt.Fprintf(w, "%s\t%d\n", name, metric.Uint64)
+				case profile.MetricKindFloat64:
+					fmt.Fprintf(w, "%s\t%g\n", name, metric.Float64)
+				case profile.MetricKindFloat64Histogram:
+					var samples uint64
+					for _, count := range metric.Histogram.Counts {
+						samples += count
+					}
+					fmt.Fprintf(w, "%s\thistogram of %d samples\n", name, samples)
+				}
+			}
+			return w.Flush()
+		},
+	}
+)
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(pingCmd)
+}
+
+var (
+	pingCmd = &cobra.Command{
+		Use:     "ping",
+		Short:   "Ping the agent",
+		Long:    `Ping the agent where this server is connected and print the round-trip time`,
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return errInvalidArguments
+			}
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			latency, err := client.Ping(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Reply from agent in", latency)
+			return nil
+		},
+	}
+)
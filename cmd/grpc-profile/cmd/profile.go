@@ -1,22 +1,73 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	profile "github.com/chanchal1987/grpc-profile"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
 	rootCmd.AddCommand(profileCmd)
+
+	profileCmd.Flags().IntVar(&profileDebug, "debug", 0, "Debug level of lookup profiles. 0 writes protobuf, 1 writes legacy text and 2 writes goroutine stack dumps")
+	profileCmd.Flags().BoolVar(&profileCompress, "compress", false, "Compress the profile on the wire")
+	profileCmd.Flags().StringVar(&profileFormat, "format", "pprof", "Output format. One of pprof or collapsed (collapsed stacks for FlameGraph and speedscope)")
+	profileCmd.Flags().IntVar(&profileSampleIndex, "sample-index", 0, "Index of the sample type written by the collapsed format")
+	profileCmd.Flags().BoolVar(&profileGCBefore, "gc-before", false, "Run a garbage collection on the agent before writing a heap profile")
+	profileCmd.Flags().StringVar(&profileSampleType, "sample-type", "", "Keep only this sample type of lookup profiles, e.g. inuse_space or alloc_objects")
+	profileCmd.Flags().StringToStringVar(&profileLabels, "label", nil, "Keep only the samples with this pprof label, e.g. request=checkout. Can be repeated, all labels have to match")
+	profileCmd.Flags().BoolVar(&profileKeep, "keep", false, "Keep the downloaded profile in '--keep-dir' and write the merge of all the profiles kept for the server with the same type, '--sample-type' and '--label's, so profiles collected over several runs can be analysed together")
+	profileCmd.Flags().StringVar(&keepDir, "keep-dir", defaultKeepDir, "Directory of the profiles kept with '--keep'")
+	profileCmd.Flags().BoolVar(&profileWait, "wait", false, "Wait for a CPU or trace profile already running on the agent to complete instead of failing. The wait counts towards '--timeout'")
 }
 
 var (
+	lookupTypes = map[string]profile.LookupType{
+		"heap":          profile.HeapType,
+		"memory":        profile.HeapType,
+		"mutex":         profile.MutexType,
+		"block":         profile.BlockType,
+		"threadcreate":  profile.ThreadCreateType,
+		"thread-create": profile.ThreadCreateType,
+		"goroutine":     profile.GoRoutineType,
+		"go-routine":    profile.GoRoutineType,
+		"allocs":        profile.AllocsType,
+	}
+	nonLookupTypes = map[string]profile.NonLookupType{
+		"cpu":   profile.CPUType,
+		"trace": profile.TraceType,
+	}
+
+	profileDebug       int
+	profileCompress    bool
+	profileFormat      string
+	profileSampleIndex int
+	profileSampleType  string
+	profileGCBefore    bool
+	profileLabels      map[string]string
+	profileKeep        bool
+	profileWait        bool
+
+	// defaultKeepDir is the default directory of the profiles kept with '--keep'
+	defaultKeepDir = filepath.Join(home, "."+applName+"-profiles")
+	keepDir        string
+
 	profileCmd = &cobra.Command{
-		Use:     "profile <profile-type> [duration] <file-name>",
+		Use:     "profile <profile-type> [duration] [file-name|-]",
 		Short:   "Run profile on remote server",
-		Long:    `Run profile on remote server where the agent is running`,
+		Long:    `Run profile on remote server where the agent is running. Use "-" as file name to write to stdout. Without a file name the profile is written to "<host>-<type>-<time>.pb.gz" ("<host>-trace-<time>.out" for traces) in the current directory`,
+		Example: applName + " profile heap\n" + applName + " profile heap heap.pb.gz\n" + applName + " profile cpu 30s cpu.pb.gz",
 		PreRunE: connect,
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			if len(args) == 0 {
@@ -26,6 +77,7 @@ var (
 					"block",
 					"threadcreate", "thread-create",
 					"goroutine", "go-routine",
+					"allocs",
 					"cpu",
 					"trace",
 				}, cobra.ShellCompDirectiveNoFileComp
@@ -33,57 +85,161 @@ var (
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		},
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			if len(args) == 2 {
-				var file *os.File
-				file, err = os.Create(args[1])
+			switch {
+			case profileFormat != "pprof" && profileFormat != "collapsed":
+				return fmt.Errorf("unknown format %q", profileFormat)
+			case profileFormat == "collapsed" && (profileDebug != 0 || (len(args) > 0 && args[0] == "trace")):
+				return errors.New("collapsed format needs a pprof profile, it can not be used with --debug or trace")
+			case profileKeep && (profileDebug != 0 || (len(args) > 0 && args[0] == "trace")):
+				return errors.New("--keep needs a pprof profile, it can not be used with --debug or trace")
+			}
+			if profileCompress {
+				err = client.SetCallOption(profile.WithCompression())
+				if err != nil {
+					return
+				}
+			}
+			if len(args) == 0 {
+				return errInvalidArguments
+			}
+			if prof, ok := lookupTypes[args[0]]; ok && len(args) <= 2 {
+				ctx, cancel := withTimeout(cmd.Context(), 0)
+				defer cancel()
+				var file io.WriteCloser
+				file, err = profileOutput(ctx, args[0], args[1:], ".pb.gz")
 				if err != nil {
 					return
 				}
 				defer func() {
-					err = file.Close()
+					if closeErr := file.Close(); err == nil {
+						err = closeErr
+					}
 				}()
-				var prof profile.LookupType
-				switch args[0] {
-				case "heap", "memory":
-					prof = profile.HeapType
-				case "mutex":
-					prof = profile.MutexType
-				case "block":
-					prof = profile.BlockType
-				case "threadcreate", "thread-create":
-					prof = profile.ThreadCreateType
-				case "goroutine", "go-routine":
-					prof = profile.GoRoutineType
-				default:
-					return errInvalidArguments
+				options := []profile.LookupOption{profile.LookupDebug(profileDebug)}
+				if profileSampleType != "" {
+					options = append(options, profile.LookupSampleType(profileSampleType))
+				}
+				if profileGCBefore {
+					options = append(options, profile.LookupGCBefore())
+				}
+				if len(profileLabels) != 0 {
+					options = append(options, profile.LookupLabelFilters(profileLabels))
+				}
+				writer, finish := formatOutput(file)
+				var meta *profile.ProfileMeta
+				if profileKeep {
+					meta, err = client.KeepLookupProfile(ctx, prof, writer, options...)
+				} else {
+					_, meta, err = client.WriteLookupProfile(ctx, prof, writer, options...)
+				}
+				if err != nil {
+					return
+				}
+				if meta != nil {
+					for _, warning := range meta.Warnings {
+						fmt.Fprintln(os.Stderr, "Warning:", warning)
+					}
 				}
-				return client.LookupProfile(cmd.Context(), prof, file)
-			} else if len(args) == 3 {
+				return finish()
+			} else if prof, ok := nonLookupTypes[args[0]]; ok && (len(args) == 2 || len(args) == 3) {
 				var dur time.Duration
 				dur, err = time.ParseDuration(args[1])
 				if err != nil {
 					return
 				}
-				var file *os.File
-				file, err = os.Create(args[2])
+				ctx, cancel := withTimeout(cmd.Context(), dur)
+				defer cancel()
+				ext := ".pb.gz"
+				if prof == profile.TraceType {
+					ext = ".out"
+				}
+				var file io.WriteCloser
+				file, err = profileOutput(ctx, args[0], args[2:], ext)
 				if err != nil {
 					return
 				}
 				defer func() {
-					err = file.Close()
+					if closeErr := file.Close(); err == nil {
+						err = closeErr
+					}
 				}()
-				var prof profile.NonLookupType
-				switch args[0] {
-				case "cpu":
-					prof = profile.CPUType
-				case "trace":
-					prof = profile.TraceType
-				default:
-					return errInvalidArguments
+				writer, finish := formatOutput(file)
+				err = nonLookupProfile(ctx, prof, dur, writer)
+				if err != nil {
+					return
 				}
-				return client.NonLookupProfile(cmd.Context(), prof, dur, file)
+				return finish()
 			}
 			return errInvalidArguments
 		},
 	}
 )
+
+// profileOutput will create the output named by args, or a file with the default name for the profile type if args is
+// empty. The default name is printed
+func profileOutput(ctx context.Context, profileType string, args []string, ext string) (io.WriteCloser, error) {
+	if len(args) != 0 {
+		return createOutput(args[0])
+	}
+	if profileFormat == "collapsed" || profileDebug != 0 {
+		ext = ".txt"
+	}
+	info, err := client.GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := defaultProfileName(info.ProcessStats.Hostname, profileType, time.Now(), ext)
+	fmt.Println("Writing profile to", name)
+	return createOutput(name)
+}
+
+// defaultProfileNameLayout is the time layout of the default profile names. Unlike RFC 3339 it has no ':', which is
+// not allowed in Windows file names
+const defaultProfileNameLayout = "20060102T150405Z"
+
+// defaultProfileName will return "<host>-<type>-<UTC time><ext>", e.g. "web-1-heap-20260102T150405Z.pb.gz", with the
+// characters not allowed in file names replaced in the host name
+func defaultProfileName(host, profileType string, t time.Time, ext string) string {
+	if host == "" {
+		host = "unknown"
+	}
+	host = strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(host)
+	return host + "-" + profileType + "-" + t.UTC().Format(defaultProfileNameLayout) + ext
+}
+
+// formatOutput will return the writer to receive a profile into and a function writing it to file in the format
+// selected with '--format' once it is received
+func formatOutput(file io.Writer) (io.Writer, func() error) {
+	if profileFormat != "collapsed" {
+		return file, func() error { return nil }
+	}
+	var buf bytes.Buffer
+	return &buf, func() error {
+		return profile.WriteCollapsedIndex(&buf, file, profileSampleIndex)
+	}
+}
+
+// nonLookupWaitInterval is how often `nonLookupProfile()` retries while a profile of the same type is running
+const nonLookupWaitInterval = time.Second
+
+// nonLookupProfile will write a non lookup profile to writer, or with '--keep' the merge of the kept profiles. With
+// '--wait' it is retried while a profile of the same type is running on the agent, until ctx is done
+func nonLookupProfile(ctx context.Context, prof profile.NonLookupType, dur time.Duration, writer io.Writer) error {
+	for {
+		var err error
+		if profileKeep {
+			err = client.KeepNonLookupProfile(ctx, prof, dur, profileLabels, writer)
+		} else {
+			err = client.NonLookupProfileFiltered(ctx, prof, dur, profileLabels, writer)
+		}
+		if !profileWait || status.Code(err) != codes.FailedPrecondition {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Waiting for the running", prof, "profile to complete")
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(nonLookupWaitInterval):
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	profile "github.com/chanchal1987/grpc-profile"
+	"github.com/chanchal1987/grpc-profile/agent"
+	pprofile "github.com/google/pprof/profile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startCLIAgent will start an agent on a free local port for the commands run with `runCLI()` and return its address
+func startCLIAgent(t *testing.T) string {
+	t.Helper()
+	server, err := agent.NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, _, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	return addr.String()
+}
+
+// runCLI will run the command line args against the agent on addr with a temporary config file. The flags of the
+// profile command are reset afterwards, as cobra keeps their values between runs
+func runCLI(t *testing.T, addr string, args ...string) error {
+	t.Helper()
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(config, nil, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		insecure, clientConnected = false, false
+		profileKeep, profileWait, profileSampleType, keepDir = false, false, "", defaultKeepDir
+		downloadSampleType, downloadLabels = "", nil
+	}()
+	rootCmd.SetArgs(append([]string{"--config", config, "--insecure", "-s", addr}, args...))
+	return rootCmd.Execute()
+}
+
+func TestProfileKeep(t *testing.T) {
+	addr := startCLIAgent(t)
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept")
+	merged := filepath.Join(dir, "merged.pb.gz")
+
+	for i := 0; i < 2; i++ {
+		err := runCLI(t, addr, "profile", "heap", merged, "--keep", "--keep-dir", kept)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	serverDir := filepath.Join(kept, url.QueryEscape(addr), "heap")
+	allDir, inuseDir := filepath.Join(serverDir, "all"), filepath.Join(serverDir, "sample-type=inuse_space")
+	files, err := filepath.Glob(filepath.Join(allDir, "*.pb.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("kept heap profiles: got %d, want 2", len(files))
+	}
+	var want int64
+	for _, file := range files {
+		want += sampleTotal(t, file)
+	}
+	if got := sampleTotal(t, merged); got != want {
+		t.Errorf("total of the merged profile: got %d, want the sum of the kept profiles %d", got, want)
+	}
+
+	// A profile with a single sample type can not be merged with the others, so it is kept apart
+	err = runCLI(t, addr, "profile", "heap", merged, "--keep", "--keep-dir", kept, "--sample-type", "inuse_space")
+	if err != nil {
+		t.Fatalf("keeping a heap profile with a sample type: %v", err)
+	}
+	files, err = filepath.Glob(filepath.Join(inuseDir, "*.pb.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("kept inuse_space heap profiles: got %d, want 1", len(files))
+	}
+}
+
+func TestProfileWait(t *testing.T) {
+	addr := startCLIAgent(t)
+	output := filepath.Join(t.TempDir(), "cpu.pb.gz")
+
+	// Another client runs a CPU profile for a second
+	other, err := profile.Dial(context.Background(), addr, profile.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Stop()
+	done := make(chan error, 1)
+	go func() {
+		done <- other.NonLookupProfile(context.Background(), profile.CPUType, time.Second, ioutil.Discard)
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	err = runCLI(t, addr, "profile", "cpu", "100ms", output)
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("CPU profile while another one runs: got %v (%v), want %v", code, err, codes.FailedPrecondition)
+	}
+	err = runCLI(t, addr, "profile", "cpu", "100ms", output, "--wait")
+	if err != nil {
+		t.Fatalf("CPU profile waiting for the running one: %v", err)
+	}
+	if err = <-done; err != nil {
+		t.Fatal(err)
+	}
+	sampleTotal(t, output)
+}
+
+// sampleTotal will return the sum of the first value of the samples of the profile in file
+func sampleTotal(t *testing.T, file string) int64 {
+	t.Helper()
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	p, err := pprofile.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total int64
+	for _, sample := range p.Sample {
+		total += sample.Value[0]
+	}
+	return total
+}
+
+func TestProfileDefaultName(t *testing.T) {
+	addr := startCLIAgent(t)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	err = runCLI(t, addr, "profile", "heap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(host)) + `-heap-\d{8}T\d{6}Z\.pb\.gz$`)
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || !pattern.MatchString(filepath.Base(files[0])) {
+		t.Fatalf("profile without a file name: got files %v, want one matching %s", files, pattern)
+	}
+	sampleTotal(t, files[0])
+
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.FixedZone("IST", 5*3600+1800))
+	if got, want := defaultProfileName("db:5432", "cpu", at, ".pb.gz"), "db_5432-cpu-20260102T093405Z.pb.gz"; got != want {
+		t.Errorf("default profile name: got %s, want %s", got, want)
+	}
+}
+
+func TestProfileStdout(t *testing.T) {
+	addr := startCLIAgent(t)
+
+	out, err := captureStdout(t, func() error { return runCLI(t, addr, "profile", "heap", "-") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pprofile.ParseData(out); err != nil {
+		t.Errorf("heap profile written to stdout: %v", err)
+	}
+
+	out, err = captureStdout(t, func() error { return runCLI(t, addr, "profile", "cpu", "100ms", "-") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pprofile.ParseData(out); err != nil {
+		t.Errorf("CPU profile written to stdout: %v", err)
+	}
+}
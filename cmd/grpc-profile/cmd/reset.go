@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(resetCmd)
+	resetCmd.Flags().BoolVar(&resetAll, "all", false, "Reset every variable")
+}
+
+var (
+	resetAll bool
+
+	resetCmd = &cobra.Command{
+		Use:               "reset <variable|--all>",
+		Short:             "Reset variable in agent",
+		Long:              `Reset a variable, or every variable with --all, in the agent where this server is connected to its value when the agent started`,
+		Example:           applName + " reset MemProfRate\n" + applName + " reset --all",
+		PreRunE:           connect,
+		ValidArgsFunction: completeVariable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if resetAll {
+				if len(args) != 0 {
+					return errInvalidArguments
+				}
+				ctx, cancel := withTimeout(cmd.Context(), 0)
+				defer cancel()
+				prev, err := client.ResetAll(ctx)
+				if err != nil {
+					return err
+				}
+				names := make([]string, 0, len(setList))
+				for name := range setList {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					if pRt, ok := prev[setList[name]]; ok {
+						fmt.Println("Reset value of", name, "from", pRt)
+					}
+				}
+				return nil
+			}
+			if len(args) != 1 {
+				return errInvalidArguments
+			}
+			val, ok := setList[args[0]]
+			if !ok {
+				return errors.New("unknown variable")
+			}
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			pRt, err := client.Reset(ctx, val)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Reset value of", args[0], "from", pRt)
+			return nil
+		},
+	}
+)
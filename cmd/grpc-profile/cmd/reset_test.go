@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestReset(t *testing.T) {
+	initial := runtime.MemProfileRate
+	defer func() {
+		runtime.MemProfileRate = initial
+		resetAll = false
+	}()
+	addr := startCLIAgent(t)
+
+	out, err := captureStdout(t, func() error { return runCLI(t, addr, "set", "MemProfRate", "1234") })
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	if runtime.MemProfileRate != 1234 {
+		t.Fatalf("MemProfileRate after set: got %d, want 1234", runtime.MemProfileRate)
+	}
+	out, err = captureStdout(t, func() error { return runCLI(t, addr, "reset", "MemProfRate") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.MemProfileRate != initial {
+		t.Errorf("MemProfileRate after reset: got %d, want the initial %d", runtime.MemProfileRate, initial)
+	}
+	if want := "Reset value of MemProfRate from 1234\n"; string(out) != want {
+		t.Errorf("reset output: got %q, want %q", out, want)
+	}
+
+	_, err = captureStdout(t, func() error { return runCLI(t, addr, "set", "MemProfRate", "4321") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = captureStdout(t, func() error { return runCLI(t, addr, "reset", "--all") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.MemProfileRate != initial {
+		t.Errorf("MemProfileRate after reset --all: got %d, want the initial %d", runtime.MemProfileRate, initial)
+	}
+	resetAll = false
+
+	out, err = captureStdout(t, func() error { return runCLI(t, addr, "__complete", "reset", "Mem") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "MemProfRate\n") {
+		t.Errorf("completion of reset: got %q, want MemProfRate", out)
+	}
+}
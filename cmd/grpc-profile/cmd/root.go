@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	profile "github.com/chanchal1987/grpc-profile"
 	"github.com/spf13/cobra"
@@ -25,8 +29,22 @@ var (
 
 	errInvalidArguments = errors.New("invalid argument(s)")
 
-	cfgFile string
-	rootCmd = &cobra.Command{
+	// envVars maps the global settings to the environment variables they are read from. A flag takes precedence over
+	// its environment variable, which takes precedence over the config file
+	envVars = map[string]string{
+		"server":   "GRPC_PROFILE_SERVER",
+		"cert":     "GRPC_PROFILE_CERT",
+		"insecure": "GRPC_PROFILE_INSECURE",
+		"timeout":  "GRPC_PROFILE_TIMEOUT",
+	}
+
+	// savedKeys are the settings written to the config file when they are set with their flag. '--insecure' and
+	// '--timeout' are never saved, so a plaintext connection has to be requested on every run
+	savedKeys = []string{"server", "cert"}
+
+	cfgFile  string
+	insecure bool
+	rootCmd  = &cobra.Command{
 		Use:   applName,
 		Short: applShortUsage,
 		Long:  applLongUsage,
@@ -37,7 +55,7 @@ var (
 					return err
 				}
 			}
-			return viper.WriteConfig()
+			return saveConfig(cmd)
 		},
 	}
 )
@@ -54,8 +72,10 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/."+applName+")")
-	rootCmd.PersistentFlags().StringP("server", "s", "", "Address of the remote server where agent is running")
-	rootCmd.PersistentFlags().String("cert", "", "Path to the TLS certificate. This will enable TLS authnetication")
+	rootCmd.PersistentFlags().StringP("server", "s", "", "Address of the remote server where agent is running (env "+envVars["server"]+")")
+	rootCmd.PersistentFlags().String("cert", "", "Path to the TLS certificate. This will enable TLS authnetication (env "+envVars["cert"]+")")
+	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "Allow a plaintext connection to the agent when '--cert' is not set (env "+envVars["insecure"]+")")
+	rootCmd.PersistentFlags().Duration("timeout", 60*time.Second, "Timeout of every request to the agent. 0 means no timeout (env "+envVars["timeout"]+")")
 	if err := viper.BindPFlag("server", rootCmd.PersistentFlags().Lookup("server")); err != nil {
 		fmt.Printf("%v\n", err)
 		os.Exit(1)
@@ -64,6 +84,19 @@ func init() {
 		fmt.Printf("%v\n", err)
 		os.Exit(1)
 	}
+	if err := viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout")); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	for key, env := range envVars {
+		if key == "insecure" {
+			continue
+		}
+		if err := viper.BindEnv(key, env); err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+	}
 }
 
 func initConfig() {
@@ -94,19 +127,113 @@ func initConfig() {
 	}
 }
 
+// saveConfig will write the settings of savedKeys which are set with their flag to the config file. The other settings
+// of the file are kept, except the ones which must not be saved
+func saveConfig(cmd *cobra.Command) error {
+	file := viper.New()
+	file.SetConfigFile(viper.ConfigFileUsed())
+	if err := file.ReadInConfig(); err != nil {
+		return err
+	}
+	settings := file.AllSettings()
+	for _, key := range []string{"insecure", "timeout"} {
+		delete(settings, key)
+	}
+	for _, key := range savedKeys {
+		if flag := cmd.Flags().Lookup(key); flag != nil && flag.Changed {
+			settings[key] = flag.Value.String()
+		}
+	}
+
+	config := viper.New()
+	config.SetConfigFile(viper.ConfigFileUsed())
+	for key, value := range settings {
+		config.Set(key, value)
+	}
+	return config.WriteConfig()
+}
+
+// allowInsecure will report whether a plaintext connection was requested with '--insecure' or its environment
+// variable. It is never read from the config file
+func allowInsecure() (bool, error) {
+	if insecure {
+		return true, nil
+	}
+	value, ok := os.LookupEnv(envVars["insecure"])
+	if !ok || value == "" {
+		return false, nil
+	}
+	allow, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %w", envVars["insecure"], err)
+	}
+	return allow, nil
+}
+
+// nopWriteCloser will keep os.Stdout open when an output is closed
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// createOutput will create the output file name, or return stdout if name is "-"
+func createOutput(name string) (io.WriteCloser, error) {
+	if name == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return os.Create(name)
+}
+
+// withTimeout will derive a context from parent which is cancelled after the '--timeout' and extra, e.g. the duration
+// of a profile, have elapsed. A timeout of 0 disables the deadline
+func withTimeout(parent context.Context, extra time.Duration) (context.Context, context.CancelFunc) {
+	timeout := viper.GetDuration("timeout")
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout+extra)
+}
+
+// errInsecure is returned when neither '--cert' nor '--insecure' is set
+var errInsecure = errors.New("refusing insecure connection; pass --insecure or --cert")
+
+func dial(ctx context.Context, address string) (*profile.Client, error) {
+	var options []profile.ClientOption
+
+	cert := viper.GetString("cert")
+	insecure, err := allowInsecure()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case cert != "" && insecure:
+		return nil, errors.New("'--cert' and '--insecure' can not be used together")
+	case cert != "":
+		options = append(options, profile.WithTLS(cert))
+	case insecure:
+		fmt.Fprintln(os.Stderr, "Warning: connecting to", address, "without TLS")
+		options = append(options, profile.WithInsecure())
+	default:
+		return nil, errInsecure
+	}
+	if keepDir != "" {
+		options = append(options, profile.WithKeepDir(keepDir))
+	}
+	return profile.Dial(ctx, address, options...)
+}
+
 func connect(cmd *cobra.Command, _ []string) error {
 	address := viper.GetString("server")
-	cert := viper.GetString("cert")
 	if address == "" {
 		return errors.New("please set server using global flag '--server'")
 	}
-	var options []*profile.DialOption
-
-	if cert != "" {
-		options = append(options, profile.DialAuthTypeTLS(cert))
-	}
+	ctx, cancel := withTimeout(cmd.Context(), 0)
+	defer cancel()
 	var err error
-	client, err = profile.NewClient(cmd.Context(), address, options...)
+	client, err = dial(ctx, address)
 	if err != nil {
 		return err
 	}
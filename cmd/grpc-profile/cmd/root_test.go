@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDialRefusesInsecure(t *testing.T) {
+	insecure = false
+	viper.Set("cert", "")
+	defer viper.Set("cert", nil)
+	os.Unsetenv(envVars["insecure"])
+
+	_, err := dial(context.Background(), "127.0.0.1:1")
+	if !errors.Is(err, errInsecure) {
+		t.Fatalf("dial without --insecure and --cert: got %v, want %v", err, errInsecure)
+	}
+}
+
+func TestSaveConfigSkipsInsecure(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(file, []byte("insecure: true\ntimeout: 5s\nother: kept\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(file)
+	defer func() { insecure = false }()
+
+	err = rootCmd.ParseFlags([]string{"--insecure", "--server", "127.0.0.1:8080", "--timeout", "10s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = saveConfig(rootCmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"insecure", "timeout"} {
+		if strings.Contains(string(content), key) {
+			t.Errorf("config contains %q:\n%s", key, content)
+		}
+	}
+	for _, line := range []string{"server: 127.0.0.1:8080", "other: kept"} {
+		if !strings.Contains(string(content), line) {
+			t.Errorf("config does not contain %q:\n%s", line, content)
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	// The listener accepts connections but never answers, like a hung agent
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listen.Close()
+	go func() {
+		for {
+			conn, err := listen.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	// cobra keeps the flag value between runs
+	defer rootCmd.PersistentFlags().Set("timeout", rootCmd.PersistentFlags().Lookup("timeout").DefValue)
+
+	start := time.Now()
+	err = runCLI(t, listen.Addr().String(), "--timeout", "200ms", "info")
+	if code := status.Code(err); code != codes.DeadlineExceeded && !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("info against a hung agent: got %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("info against a hung agent returned after %v, want about 200ms", elapsed)
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	addr := startCLIAgent(t)
+	// The listener is closed, so nothing answers on the address
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dead := listen.Addr().String()
+	_ = listen.Close()
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	err = os.WriteFile(config, []byte("server: "+dead+"\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// cobra keeps the flag values of earlier runs
+	server := rootCmd.PersistentFlags().Lookup("server")
+	resetServer := func() {
+		_ = server.Value.Set("")
+		server.Changed = false
+	}
+	resetServer()
+	defer resetServer()
+	for key, value := range map[string]string{"server": addr, "insecure": "true"} {
+		os.Setenv(envVars[key], value)
+		defer os.Unsetenv(envVars[key])
+	}
+
+	// The environment takes precedence over the config file
+	rootCmd.SetArgs([]string{"--config", config, "ping"})
+	if err = rootCmd.Execute(); err != nil {
+		t.Errorf("server from %s: %v", envVars["server"], err)
+	}
+	clientConnected = false
+
+	// A flag takes precedence over the environment
+	os.Setenv(envVars["server"], dead)
+	rootCmd.SetArgs([]string{"--config", config, "--server", addr, "ping"})
+	if err = rootCmd.Execute(); err != nil {
+		t.Errorf("server from the flag: %v", err)
+	}
+	clientConnected = false
+}
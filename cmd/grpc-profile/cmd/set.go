@@ -19,26 +19,15 @@ var (
 		"CPUProfRate":          profile.CPUProfRate,
 		"MutexProfileFraction": profile.MutexProfileFraction,
 		"BlockProfileRate":     profile.BlockProfileRate,
+		"GCPercent":            profile.GCPercent,
 	}
 
 	setCmd = &cobra.Command{
-		Use:     "set <variable> <value>",
-		Short:   "Set veriable in agent",
-		Long:    `Set a variable in the agent where this server is connected`,
-		PreRunE: connect,
-		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-			if len(args) == 0 {
-				argList := make([]string, len(setList))
-				i := 0
-				for k := range setList {
-					argList[i] = k
-					i++
-				}
-
-				return argList, cobra.ShellCompDirectiveNoFileComp
-			}
-			return nil, cobra.ShellCompDirectiveNoFileComp
-		},
+		Use:               "set <variable> <value>",
+		Short:             "Set veriable in agent",
+		Long:              `Set a variable in the agent where this server is connected`,
+		PreRunE:           connect,
+		ValidArgsFunction: completeVariable,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 2 {
 				return errInvalidArguments
@@ -51,12 +40,28 @@ var (
 			if err != nil {
 				return err
 			}
-			pRt, err := client.Set(cmd.Context(), val, rt)
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			pRt, err := client.Set(ctx, val, rt)
 			if err != nil {
 				return err
 			}
-			fmt.Println("Changed valus of", args[0], "from", pRt, "to", rt)
+			fmt.Println("Changed value of", args[0], "from", pRt, "to", rt)
 			return nil
 		},
 	}
 )
+
+func completeVariable(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		argList := make([]string, len(setList))
+		i := 0
+		for k := range setList {
+			argList[i] = k
+			i++
+		}
+
+		return argList, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	initial := runtime.MemProfileRate
+	defer func() { runtime.MemProfileRate = initial }()
+	addr := startCLIAgent(t)
+
+	prev := initial
+	for _, value := range []int{1234, 4321} {
+		out, err := captureStdout(t, func() error { return runCLI(t, addr, "set", "MemProfRate", fmt.Sprint(value)) })
+		if err != nil {
+			t.Fatalf("%v\n%s", err, out)
+		}
+		if want := fmt.Sprintf("Changed value of MemProfRate from %d to %d\n", prev, value); string(out) != want {
+			t.Errorf("set output: got %q, want %q", out, want)
+		}
+		prev = value
+	}
+
+	for _, args := range [][]string{{"set", "Unknown", "1"}, {"set", "MemProfRate", "many"}, {"set", "MemProfRate"}} {
+		if _, err := captureStdout(t, func() error { return runCLI(t, addr, args...) }); err == nil {
+			t.Errorf("%v: got no error", args)
+		}
+	}
+	if runtime.MemProfileRate != prev {
+		t.Errorf("MemProfileRate after invalid arguments: got %d, want %d", runtime.MemProfileRate, prev)
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().DurationVar(&snapshotCPUDuration, "cpu-duration", 10*time.Second, "Duration of the CPU profile")
+}
+
+var (
+	snapshotCPUDuration time.Duration
+
+	snapshotCmd = &cobra.Command{
+		Use:     "snapshot <file.tar|->",
+		Short:   "Download a tar archive with a CPU profile, a heap profile, a goroutine dump and the agent information",
+		Long:    `Download a tar archive with a CPU profile (cpu.pb.gz), a heap profile (heap.pb.gz), a goroutine dump (goroutine.txt) and the agent information (info.json) collected in one request, for a quick overview of the process. Use "-" as file name to write to stdout`,
+		Example: applName + " snapshot snapshot.tar\n" + applName + " snapshot snapshot.tar --cpu-duration 5s",
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 1 {
+				return errInvalidArguments
+			}
+			var file io.WriteCloser
+			file, err = createOutput(args[0])
+			if err != nil {
+				return
+			}
+			defer func() {
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
+			}()
+
+			ctx, cancel := withTimeout(cmd.Context(), snapshotCPUDuration)
+			defer cancel()
+			return client.Snapshot(ctx, snapshotCPUDuration, file)
+		},
+	}
+)
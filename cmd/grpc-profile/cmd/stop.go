@@ -29,7 +29,9 @@ var (
 			default:
 				return errInvalidArguments
 			}
-			return client.StopNonLookupProfile(cmd.Context(), prof)
+			ctx, cancel := withTimeout(cmd.Context(), 0)
+			defer cancel()
+			return client.StopNonLookupProfile(ctx, prof)
 		},
 	}
 )
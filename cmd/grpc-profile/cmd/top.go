@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	profile "github.com/chanchal1987/grpc-profile"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+}
+
+var (
+	topCmd = &cobra.Command{
+		Use:     "top <profile-type> [duration] [n]",
+		Short:   "Show the top functions of a profile",
+		Long:    `Run profile on remote server where the agent is running and show its top n (default 10) functions by flat value. The duration is required for cpu profiles`,
+		Example: applName + " top heap\n" + applName + " top heap 20\n" + applName + " top cpu 10s 20",
+		PreRunE: connect,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return []string{
+					"heap", "memory",
+					"mutex",
+					"block",
+					"threadcreate", "thread-create",
+					"goroutine", "go-routine",
+					"allocs",
+					"cpu",
+				}, cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errInvalidArguments
+			}
+
+			var top []profile.TopEntry
+			if prof, ok := lookupTypes[args[0]]; ok {
+				n, err := topN(args[1:])
+				if err != nil {
+					return err
+				}
+				ctx, cancel := withTimeout(cmd.Context(), 0)
+				defer cancel()
+				top, err = client.ProfileTop(ctx, prof, n)
+				if err != nil {
+					return err
+				}
+			} else if args[0] == "cpu" && len(args) >= 2 {
+				dur, err := time.ParseDuration(args[1])
+				if err != nil {
+					return err
+				}
+				n, err := topN(args[2:])
+				if err != nil {
+					return err
+				}
+				ctx, cancel := withTimeout(cmd.Context(), dur)
+				defer cancel()
+				top, err = client.NonLookupProfileTop(ctx, profile.CPUType, dur, n)
+				if err != nil {
+					return err
+				}
+			} else {
+				return errInvalidArguments
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+			fmt.Fprintln(w, "flat\tcum\tunit\t\tfunction")
+			for _, entry := range top {
+				fmt.Fprintf(w, "%d\t%d\t%s\t\t%s\n", entry.Flat, entry.Cum, entry.Unit, entry.Function)
+			}
+			return w.Flush()
+		},
+	}
+)
+
+// topN will parse the optional number of functions to show
+func topN(args []string) (int, error) {
+	switch len(args) {
+	case 0:
+		return 10, nil
+	case 1:
+		return strconv.Atoi(args[0])
+	default:
+		return 0, errInvalidArguments
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	profile "github.com/chanchal1987/grpc-profile"
+	"github.com/spf13/cobra"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
+
+var (
+	watchCmd = &cobra.Command{
+		Use:     "watch [interval]",
+		Short:   "Watch information about the server",
+		Long:    `Watch runtime information about the server where the agent is running, redrawn every interval (default 1s)`,
+		PreRunE: connect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			interval := time.Second
+			switch len(args) {
+			case 0:
+			case 1:
+				var err error
+				interval, err = time.ParseDuration(args[0])
+				if err != nil {
+					return err
+				}
+			default:
+				return errInvalidArguments
+			}
+
+			return client.WatchInfo(cmd.Context(), interval, func(info *profile.InfoType) error {
+				fmt.Print(clearScreen)
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintf(w, "Time\t%s\n", time.Now().Format(time.RFC3339))
+				fmt.Fprintf(w, "Hostname\t%s\n", info.ProcessStats.Hostname)
+				fmt.Fprintf(w, "PID\t%d\n", info.ProcessStats.PID)
+				fmt.Fprintf(w, "GOMAXPROCS\t%d\n", info.GOMAXPROCS)
+				fmt.Fprintf(w, "Goroutines\t%d\n", info.NumGoroutine)
+				fmt.Fprintf(w, "User goroutines\t%d\n", info.NumUserGoroutines)
+				fmt.Fprintf(w, "Cgo calls\t%d\n", info.NumCgoCall)
+				fmt.Fprintf(w, "Heap alloc\t%d\n", info.MemStats.HeapAlloc)
+				fmt.Fprintf(w, "Heap in use\t%d\n", info.MemStats.HeapInuse)
+				fmt.Fprintf(w, "Heap objects\t%d\n", info.MemStats.HeapObjects)
+				fmt.Fprintf(w, "Sys\t%d\n", info.MemStats.Sys)
+				fmt.Fprintf(w, "Num GC\t%d\n", info.MemStats.NumGC)
+				fmt.Fprintf(w, "Last GC\t%s\n", info.MemStats.LastGC.Format(time.RFC3339))
+				fmt.Fprintf(w, "GC pause total\t%s\n", info.MemStats.PauseTotalNs)
+				return w.Flush()
+			})
+		},
+	}
+)
@@ -0,0 +1,56 @@
+package profile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+// WriteCollapsed function will parse a pprof profile from reader and write it to writer as collapsed stacks
+// ("outer;inner value" per line), the input format of FlameGraph and speedscope. The first sample type is used
+func WriteCollapsed(reader io.Reader, writer io.Writer) error {
+	return WriteCollapsedIndex(reader, writer, 0)
+}
+
+// WriteCollapsedIndex function will work like `WriteCollapsed()` using the sample type at index, e.g. 1 for the
+// inuse_space values of a heap profile
+func WriteCollapsedIndex(reader io.Reader, writer io.Writer, index int) error {
+	p, err := pprofile.Parse(reader)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(p.SampleType) {
+		return fmt.Errorf("sample index %d out of range, the profile has %d sample types", index, len(p.SampleType))
+	}
+
+	values := make(map[string]int64)
+	for _, sample := range p.Sample {
+		stack := sampleStack(sample)
+		if len(stack) == 0 || sample.Value[index] == 0 {
+			continue
+		}
+		// Collapsed stacks list the outermost frame first
+		for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+			stack[i], stack[j] = stack[j], stack[i]
+		}
+		values[strings.Join(stack, ";")] += sample.Value[index]
+	}
+
+	stacks := make([]string, 0, len(values))
+	for stack := range values {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	buffered := bufio.NewWriter(writer)
+	for _, stack := range stacks {
+		if _, err = fmt.Fprintf(buffered, "%s %d\n", stack, values[stack]); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
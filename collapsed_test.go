@@ -0,0 +1,29 @@
+package profile
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCollapsed(t *testing.T) {
+	content := syntheticProfile(t,
+		Sample{Stack: []string{"hot", "handler", "main"}, Value: 70},
+		Sample{Stack: []string{"hot", "handler", "main"}, Value: 5},
+		Sample{Stack: []string{"warm", "main"}, Value: 20},
+		Sample{Stack: []string{"idle", "main"}, Value: 0},
+	)
+
+	var buf bytes.Buffer
+	err := WriteCollapsed(bytes.NewReader(content), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "main;handler;hot 75\nmain;warm 20\n"; buf.String() != want {
+		t.Errorf("collapsed stacks: got %q, want %q", buf.String(), want)
+	}
+
+	err = WriteCollapsedIndex(bytes.NewReader(content), &buf, 1)
+	if err == nil {
+		t.Error("sample index out of range: got no error")
+	}
+}
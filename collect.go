@@ -0,0 +1,94 @@
+package profile
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// collectAllLookupTypes are the lookup profiles collected by `CollectAll()`, in order
+var collectAllLookupTypes = []LookupType{HeapType, GoRoutineType, BlockType, MutexType, ThreadCreateType, AllocsType}
+
+// CollectError will be returned by `CollectAll()` with the profile types which could not be collected
+type CollectError struct {
+	// Types lists the failed profile types in the order they were collected
+	Types  []string
+	Errors map[string]error
+}
+
+func (e *CollectError) Error() string {
+	msgs := make([]string, len(e.Types))
+	for i, t := range e.Types {
+		msgs[i] = t + ": " + e.Errors[t].Error()
+	}
+	return "could not collect profiles: " + strings.Join(msgs, "; ")
+}
+
+func (e *CollectError) add(t string, err error) {
+	if e.Errors == nil {
+		e.Errors = make(map[string]error)
+	}
+	e.Types = append(e.Types, t)
+	e.Errors[t] = err
+}
+
+// CollectAll function will collect the heap, goroutine, block, mutex, threadcreate and allocs profiles, a CPU profile
+// of cpuDur and a trace of traceDur one after the other into dir, which is created if needed. The profiles are written
+// to "<type>.pb.gz" and the trace to "trace.out". A zero duration skips the CPU profile or the trace. A failing type
+// does not stop the others, the failures are returned together as a `*CollectError`
+func (client *Client) CollectAll(ctx context.Context, dir string, cpuDur, traceDur time.Duration) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	var collectErr CollectError
+	for _, t := range collectAllLookupTypes {
+		err := collectFile(filepath.Join(dir, t.String()+".pb.gz"), func(writer io.Writer) error {
+			return client.LookupProfile(ctx, t, writer)
+		})
+		if err != nil {
+			collectErr.add(t.String(), err)
+		}
+	}
+	if cpuDur > 0 {
+		err := collectFile(filepath.Join(dir, CPUType.String()+".pb.gz"), func(writer io.Writer) error {
+			return client.NonLookupProfile(ctx, CPUType, cpuDur, writer)
+		})
+		if err != nil {
+			collectErr.add(CPUType.String(), err)
+		}
+	}
+	if traceDur > 0 {
+		err := collectFile(filepath.Join(dir, TraceType.String()+".out"), func(writer io.Writer) error {
+			return client.NonLookupProfile(ctx, TraceType, traceDur, writer)
+		})
+		if err != nil {
+			collectErr.add(TraceType.String(), err)
+		}
+	}
+
+	if len(collectErr.Types) != 0 {
+		return &collectErr
+	}
+	return nil
+}
+
+// collectFile will write a profile to the file name with collect, removing the file if it fails
+func collectFile(name string, collect func(io.Writer) error) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	err = collect(file)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(name)
+	}
+	return err
+}
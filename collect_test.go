@@ -0,0 +1,59 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestCollectAll(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "profiles")
+
+	err := client.CollectAll(ctx, dir, 100*time.Millisecond, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"heap.pb.gz", "goroutine.pb.gz", "block.pb.gz", "mutex.pb.gz", "threadcreate.pb.gz", "allocs.pb.gz", "cpu.pb.gz"} {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if _, err = pprofile.ParseData(content); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(dir, "trace.out")); err != nil || info.Size() == 0 {
+		t.Errorf("trace.out: %v, want a non empty trace", err)
+	}
+
+	// A CPU profile running on the agent fails only the CPU profile of the collection
+	running := make(chan error, 1)
+	go func() {
+		running <- client.NonLookupProfile(ctx, CPUType, time.Second, ioutil.Discard)
+	}()
+	time.Sleep(200 * time.Millisecond)
+	dir = filepath.Join(t.TempDir(), "partial")
+	err = client.CollectAll(ctx, dir, 100*time.Millisecond, 0)
+	var collectErr *CollectError
+	if !errors.As(err, &collectErr) || len(collectErr.Types) != 1 || collectErr.Types[0] != "cpu" {
+		t.Errorf("collection while a CPU profile runs: got %v, want a CollectError for cpu", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "heap.pb.gz")); err != nil {
+		t.Errorf("heap profile of the partial collection: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "cpu.pb.gz")); !os.IsNotExist(err) {
+		t.Errorf("failed CPU profile file: got %v, want it removed", err)
+	}
+	if err = <-running; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,97 @@
+package profile
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/agent"
+	"github.com/chanchal1987/grpc-profile/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stalledServer is an agent whose NonLookupProfile RPC never finishes, like an agent whose profile stop deadlocks. It
+// only returns once the stream context is done and then closes returned
+type stalledServer struct {
+	*agent.Agent
+	returned chan struct{}
+}
+
+func (server *stalledServer) NonLookupProfile(in *proto.NonLookupProfileInputType, stream proto.ProfileService_NonLookupProfileServer) error {
+	defer close(server.returned)
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestNonLookupProfileDeadline(t *testing.T) {
+	profileAgent, err := agent.NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stalled := &stalledServer{Agent: profileAgent, returned: make(chan struct{})}
+	server := grpc.NewServer()
+	proto.RegisterProfileServiceServer(server, stalled)
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listen)
+	defer server.Stop()
+
+	ctx := context.Background()
+	client, err := Dial(ctx, listen.Addr().String(), WithInsecure(), WithDialOption(DialProfileSlack(200*time.Millisecond)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Stop()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.NonLookupProfile(ctx, CPUType, 100*time.Millisecond, ioutil.Discard)
+	}()
+	select {
+	case err = <-done:
+		if code := status.Code(err); code != codes.DeadlineExceeded {
+			t.Errorf("profile of a stalled agent: got %v, want %v", err, codes.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("profile of a stalled agent did not return")
+	}
+	select {
+	case <-stalled.returned:
+	case <-time.After(5 * time.Second):
+		t.Error("stalled agent did not see the deadline")
+	}
+}
+
+func TestNonLookupProfileAgentDeadline(t *testing.T) {
+	_, client := newTestClient(t)
+
+	// The agent stops the profile at the deadline of the stream instead of at the end of the duration
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := client.NonLookupProfile(ctx, CPUType, time.Minute, ioutil.Discard)
+	if code := status.Code(err); code != codes.DeadlineExceeded {
+		t.Errorf("profile beyond the deadline: got %v, want %v", err, codes.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("profile beyond the deadline took %v", elapsed)
+	}
+
+	// The CPU profiler is free again once the agent has stopped the profile
+	for i := 0; i < 50; i++ {
+		err = client.NonLookupProfile(context.Background(), CPUType, 50*time.Millisecond, ioutil.Discard)
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Errorf("profile after the deadline: %v", err)
+	}
+}
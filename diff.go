@@ -0,0 +1,39 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+// Diff function will write to writer the difference of two pprof profiles of the same type, i.e. current with the
+// sample values of base subtracted. The result can be inspected with `go tool pprof`
+func Diff(base, current io.Reader, writer io.Writer) error {
+	baseProfile, err := pprofile.Parse(base)
+	if err != nil {
+		return err
+	}
+	currentProfile, err := pprofile.Parse(current)
+	if err != nil {
+		return err
+	}
+
+	baseProfile.Scale(-1)
+	diff, err := pprofile.Merge([]*pprofile.Profile{baseProfile, currentProfile})
+	if err != nil {
+		return err
+	}
+	return diff.Write(writer)
+}
+
+// ProfileDiff will run a profile for lookup pprof type and write its difference to base, a profile of the same type
+// collected earlier, into writer. See `Diff()`
+func (client *Client) ProfileDiff(ctx context.Context, t LookupType, base io.Reader, writer io.Writer) error {
+	var buf bytes.Buffer
+	if err := client.LookupProfile(ctx, t, &buf); err != nil {
+		return err
+	}
+	return Diff(base, &buf, writer)
+}
@@ -0,0 +1,40 @@
+package profile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestDiff(t *testing.T) {
+	base := syntheticProfile(t,
+		Sample{Stack: []string{"hot", "main"}, Value: 70},
+		Sample{Stack: []string{"cold", "main"}, Value: 10},
+	)
+	current := syntheticProfile(t,
+		Sample{Stack: []string{"hot", "main"}, Value: 30},
+		Sample{Stack: []string{"cold", "main"}, Value: 25},
+		Sample{Stack: []string{"new", "main"}, Value: 5},
+	)
+
+	var buf bytes.Buffer
+	err := Diff(bytes.NewReader(base), bytes.NewReader(current), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := pprofile.Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int64)
+	for _, sample := range diff.Sample {
+		got[strings.Join(sampleStack(sample), ";")] += sample.Value[0]
+	}
+	for stack, want := range map[string]int64{"hot;main": -40, "cold;main": 15, "new;main": 5} {
+		if got[stack] != want {
+			t.Errorf("diff of %s: got %d, want %d", stack, got[stack], want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrProfileTooLarge is returned when the agent aborts a profile stream because it exceeds the configured size limit
+	ErrProfileTooLarge = errors.New("profile is too large")
+
+	// ErrNotConnected is returned by the client methods called before `Connect()` or after `Stop()`
+	ErrNotConnected = errors.New("client is not connected")
+
+	// ErrProfileNotFound is returned when the agent does not know the requested profile
+	ErrProfileNotFound = errors.New("profile not found")
+
+	// ErrUnknownProfileType is returned for a `LookupType` or `NonLookupType` which is not one of the declared types
+	ErrUnknownProfileType = errors.New("unknown profile type")
+
+	// ErrNothingKept is returned by the Download methods of the client when no profile is kept for the type
+	ErrNothingKept = errors.New("no profile is kept")
+)
+
+// Error reasons of the agent, they must match the `agent.Reason...` constants
+const (
+	reasonProfileTooLarge    = "PROFILE_TOO_LARGE"
+	reasonUnknownProfileType = "UNKNOWN_PROFILE_TYPE"
+)
+
+// reasonErrors maps the error reasons of the agent to the matching client errors
+var reasonErrors = map[string]error{
+	reasonProfileTooLarge:    ErrProfileTooLarge,
+	reasonUnknownProfileType: ErrUnknownProfileType,
+}
+
+// RPCError is the error returned by the client methods for a GRPC status error of the agent which matches one of the
+// client errors. `errors.Is` matches the client error, e.g. `ErrProfileNotFound`, and `status.FromError` still returns
+// the GRPC status
+type RPCError struct {
+	Err    error
+	Status *status.Status
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Status.Message())
+}
+
+// Unwrap function will return the matching client error
+func (e *RPCError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus function will return the GRPC status returned by the agent
+func (e *RPCError) GRPCStatus() *status.Status {
+	return e.Status
+}
+
+// wrapError will wrap the GRPC status error returned by the agent in the matching client error, if there is one
+func wrapError(err error) error {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && reasonErrors[info.Reason] != nil {
+			return &RPCError{Err: reasonErrors[info.Reason], Status: st}
+		}
+	}
+	if st.Code() == codes.NotFound {
+		return &RPCError{Err: ErrProfileNotFound, Status: st}
+	}
+	return err
+}
+
+// unaryErrorInterceptor will wrap the errors of unary RPCs with `wrapError()`
+func unaryErrorInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return wrapError(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// streamErrorInterceptor will wrap the errors of streaming RPCs with `wrapError()`
+func streamErrorInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &errorStream{ClientStream: stream}, nil
+}
+
+type errorStream struct {
+	grpc.ClientStream
+}
+
+func (stream *errorStream) RecvMsg(m interface{}) error {
+	err := stream.ClientStream.RecvMsg(m)
+	if err == nil || err == io.EOF {
+		return err
+	}
+	return wrapError(err)
+}
+
+// notConnected is the connection used by the client methods before `Connect()` and after `Stop()`
+type notConnected struct{}
+
+func (notConnected) Invoke(context.Context, string, interface{}, interface{}, ...grpc.CallOption) error {
+	return ErrNotConnected
+}
+
+func (notConnected) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, ErrNotConnected
+}
+
+// service will return the GRPC client of the agent, which fails every RPC with `ErrNotConnected` if the client is not
+// connected
+func (client *Client) service() proto.ProfileServiceClient {
+	if client.client == nil {
+		return proto.NewProfileServiceClient(notConnected{})
+	}
+	return client.client
+}
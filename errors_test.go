@@ -0,0 +1,98 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func statusWithReason(t *testing.T, code codes.Code, reason string) error {
+	t.Helper()
+	st, err := status.New(code, "message").WithDetails(&errdetails.ErrorInfo{Reason: reason})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st.Err()
+}
+
+func TestWrapError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+		code codes.Code
+	}{
+		{"too large", statusWithReason(t, codes.ResourceExhausted, reasonProfileTooLarge), ErrProfileTooLarge, codes.ResourceExhausted},
+		{"unknown type", statusWithReason(t, codes.NotFound, reasonUnknownProfileType), ErrUnknownProfileType, codes.NotFound},
+		{"not found", status.Error(codes.NotFound, "message"), ErrProfileNotFound, codes.NotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := wrapError(tc.err)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("got %v, want %v", err, tc.want)
+			}
+			var rpcErr *RPCError
+			if !errors.As(err, &rpcErr) {
+				t.Errorf("%v is not an *RPCError", err)
+			}
+			if code := status.Code(err); code != tc.code {
+				t.Errorf("status code: got %v, want %v", code, tc.code)
+			}
+		})
+	}
+
+	err := status.Error(codes.Internal, "message")
+	if wrapped := wrapError(err); wrapped != err {
+		t.Errorf("error without a client error: got %v, want it unchanged", wrapped)
+	}
+}
+
+func TestUnknownProfileType(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	err := client.LookupProfile(ctx, LookupType(42), ioutil.Discard)
+	if !errors.Is(err, ErrUnknownProfileType) {
+		t.Errorf("unknown lookup type: got %v, want %v", err, ErrUnknownProfileType)
+	}
+	err = client.NonLookupProfile(ctx, NonLookupType(42), 0, ioutil.Discard)
+	if !errors.Is(err, ErrUnknownProfileType) {
+		t.Errorf("unknown non lookup type: got %v, want %v", err, ErrUnknownProfileType)
+	}
+
+	// Enum values unknown to the agent are rejected by the agent
+	stream, err := client.service().LookupProfile(ctx, &proto.LookupProfileInputType{ProfileType: 42})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if !errors.Is(err, ErrUnknownProfileType) || status.Code(err) != codes.NotFound {
+		t.Errorf("unknown lookup type sent to the agent: got %v, want %v with %v", err, ErrUnknownProfileType, codes.NotFound)
+	}
+	if err == io.EOF {
+		t.Error("unknown lookup type sent to the agent succeeded")
+	}
+}
+
+func TestNotConnected(t *testing.T) {
+	var client Client
+	_, err := client.Ping(context.Background())
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("ping before connect: got %v, want %v", err, ErrNotConnected)
+	}
+
+	_, client2 := newTestClient(t)
+	if err := client2.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	err = client2.LookupProfile(context.Background(), HeapType, ioutil.Discard)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("lookup profile after stop: got %v, want %v", err, ErrNotConnected)
+	}
+}
@@ -1,16 +1,17 @@
 module github.com/chanchal1987/grpc-profile
 
-go 1.14
+go 1.16
 
 require (
-	github.com/golang/protobuf v1.4.0
+	github.com/golang/protobuf v1.5.2
+	github.com/google/pprof v0.0.0-20200708004538-1a94d8640e99
+	github.com/prometheus/client_golang v1.12.2
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/viper v1.4.0
 	github.com/stretchr/testify v1.5.1 // indirect
-	golang.org/x/net v0.0.0-20200421231249-e086a090c8fd
-	golang.org/x/sys v0.0.0-20200420163511-1957bb5e6d1f // indirect
-	golang.org/x/text v0.3.2 // indirect
-	google.golang.org/genproto v0.0.0-20200420144010-e5e8543f8aeb // indirect
-	google.golang.org/grpc v1.29.0
-	google.golang.org/protobuf v1.21.0
+	golang.org/x/net v0.0.0-20210525063256-abc453219eb5
+	google.golang.org/genproto v0.0.0-20200825200019-8632dd797987
+	google.golang.org/grpc v1.31.0
+	google.golang.org/protobuf v1.26.0
+	gopkg.in/yaml.v2 v2.4.0
 )
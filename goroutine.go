@@ -0,0 +1,50 @@
+package profile
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// GoroutineDelta function will read the number of goroutines of the agent, wait for d and read it again. It returns
+// both counts so that the caller can spot goroutines leaked in between
+func (client *Client) GoroutineDelta(ctx context.Context, d time.Duration) (before, after int, err error) {
+	return client.GoroutineDeltaWithProfiles(ctx, d, nil, nil)
+}
+
+// GoroutineDeltaWithProfiles function will work like GoroutineDelta and additionally write a goroutine profile to
+// beforeWriter and afterWriter at each end of the interval, so that the stacks can be diffed. A nil writer skips the
+// corresponding profile
+func (client *Client) GoroutineDeltaWithProfiles(ctx context.Context, d time.Duration, beforeWriter, afterWriter io.Writer) (before, after int, err error) {
+	before, err = client.numGoroutine(ctx, beforeWriter)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return before, 0, ctx.Err()
+	case <-timer.C:
+	}
+
+	after, err = client.numGoroutine(ctx, afterWriter)
+	if err != nil {
+		return before, 0, err
+	}
+	return before, after, nil
+}
+
+func (client *Client) numGoroutine(ctx context.Context, writer io.Writer) (int, error) {
+	info, err := client.GetInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if writer != nil {
+		if err := client.LookupProfile(ctx, GoRoutineType, writer); err != nil {
+			return 0, err
+		}
+	}
+	return info.NumGoroutine, nil
+}
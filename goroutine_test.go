@@ -0,0 +1,61 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+// leakGoroutines will start n goroutines blocked until stop is closed
+func leakGoroutines(n int, stop chan struct{}) {
+	for i := 0; i < n; i++ {
+		go func() {
+			<-stop
+		}()
+	}
+}
+
+func TestGoroutineDelta(t *testing.T) {
+	_, client := newTestClient(t)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// The goroutines leak while the delta is measured
+	time.AfterFunc(50*time.Millisecond, func() { leakGoroutines(10, stop) })
+	var beforeProfile, afterProfile bytes.Buffer
+	before, after, err := client.GoroutineDeltaWithProfiles(context.Background(), 200*time.Millisecond, &beforeProfile, &afterProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after-before < 10 {
+		t.Errorf("goroutines before and after leaking 10: got %d and %d", before, after)
+	}
+
+	leaked := func(content []byte) int64 {
+		p, err := pprofile.ParseData(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var count int64
+		for _, sample := range p.Sample {
+			for _, function := range sampleStack(sample) {
+				if function == "github.com/chanchal1987/grpc-profile.leakGoroutines.func1" {
+					count += sample.Value[0]
+				}
+			}
+		}
+		return count
+	}
+	if got := leaked(afterProfile.Bytes()) - leaked(beforeProfile.Bytes()); got != 10 {
+		t.Errorf("leaked goroutines in the profiles: got %d, want 10", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err = client.GoroutineDelta(ctx, time.Second); err == nil {
+		t.Error("goroutine delta with a cancelled context: got no error")
+	}
+}
@@ -0,0 +1,48 @@
+// Package tlsconfig builds the TLS configurations shared by the GRPC Profile Client and Agent
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// Option will set a parameter of a TLS configuration
+type Option func(*tls.Config)
+
+// MinVersion function will create an Option to set the minimum TLS version
+func MinVersion(version uint16) Option {
+	return func(config *tls.Config) {
+		config.MinVersion = version
+	}
+}
+
+// CipherSuites function will create an Option to restrict the cipher suites allowed for TLS 1.2 and older
+func CipherSuites(suites ...uint16) Option {
+	return func(config *tls.Config) {
+		config.CipherSuites = suites
+	}
+}
+
+// New function will apply options to config, with TLS 1.2 as the default minimum version
+func New(config *tls.Config, options []Option) *tls.Config {
+	config.MinVersion = tls.VersionTLS12
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// LoadCertPool function will load the PEM certificates of file into a new pool
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", file)
+	}
+	return pool, nil
+}
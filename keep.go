@@ -0,0 +1,186 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	pprofile "github.com/google/pprof/profile"
+)
+
+// keptNameLayout is the time layout of the names of the kept profiles
+const keptNameLayout = "20060102T150405.000000000Z"
+
+// WithKeepDir function will create a `ClientOption` keeping the profiles collected with `KeepLookupProfile()` and
+// `KeepNonLookupProfile()` in dir, in a sub directory for every agent address, profile type, sample type and label
+// filters
+func WithKeepDir(dir string) ClientOption {
+	return func(client *Client) error {
+		if dir == "" {
+			return errors.New("keep directory can not be empty")
+		}
+		client.keepDir = dir
+		return nil
+	}
+}
+
+// KeepLookupProfile will run a profile for lookup pprof type like `WriteLookupProfile()`, keep it in the directory set
+// with `WithKeepDir()` and write the merge of all the profiles kept for the agent with the same type, sample type and
+// label filters to writer, so profiles collected over several runs can be analysed together. The debug level must be 0
+func (client *Client) KeepLookupProfile(ctx context.Context, t LookupType, writer io.Writer, options ...LookupOption) (*ProfileMeta, error) {
+	dir, err := client.lookupKeptDir(t, options)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	_, meta, err := client.WriteLookupProfile(ctx, t, &buf, options...)
+	if err != nil {
+		return nil, err
+	}
+	return meta, client.keep(dir, buf.Bytes(), writer)
+}
+
+// KeepNonLookupProfile will run a profile for non lookup pprof type like `NonLookupProfileFiltered()`, keep it in the
+// directory set with `WithKeepDir()` and write the merge of all the profiles kept for the agent with the same type and
+// label filters to writer. `TraceType` can not be kept
+func (client *Client) KeepNonLookupProfile(ctx context.Context, t NonLookupType, d time.Duration, labelFilters map[string]string, writer io.Writer) error {
+	dir, err := client.nonLookupKeptDir(t, labelFilters)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	err = client.NonLookupProfileFiltered(ctx, t, d, labelFilters, &buf)
+	if err != nil {
+		return err
+	}
+	return client.keep(dir, buf.Bytes(), writer)
+}
+
+// DownloadLookupProfile will write the merge of the profiles kept with `KeepLookupProfile()` for the agent with the
+// same type, sample type and label filters to writer. `ErrNothingKept` is returned when no such profile is kept
+func (client *Client) DownloadLookupProfile(ctx context.Context, t LookupType, writer io.Writer, options ...LookupOption) error {
+	dir, err := client.lookupKeptDir(t, options)
+	if err != nil {
+		return err
+	}
+	return client.download(ctx, dir, writer)
+}
+
+// DownloadNonLookupProfile will write the merge of the profiles kept with `KeepNonLookupProfile()` for the agent with
+// the same type and label filters to writer. `ErrNothingKept` is returned when no such profile is kept
+func (client *Client) DownloadNonLookupProfile(ctx context.Context, t NonLookupType, labelFilters map[string]string, writer io.Writer) error {
+	dir, err := client.nonLookupKeptDir(t, labelFilters)
+	if err != nil {
+		return err
+	}
+	return client.download(ctx, dir, writer)
+}
+
+func (client *Client) lookupKeptDir(t LookupType, options []LookupOption) (string, error) {
+	if _, ok := lookupLookupType[t]; !ok {
+		return "", fmt.Errorf("%w: %d", ErrUnknownProfileType, t)
+	}
+	input := &proto.LookupProfileInputType{}
+	for _, option := range options {
+		option(input)
+	}
+	if input.Debug != 0 {
+		return "", errors.New("only the profiles with debug level 0 can be kept")
+	}
+	return client.keptDir(t.String(), input.SampleType, input.LabelFilters)
+}
+
+func (client *Client) nonLookupKeptDir(t NonLookupType, labelFilters map[string]string) (string, error) {
+	if _, ok := lookupNonLookupType[t]; !ok {
+		return "", fmt.Errorf("%w: %d", ErrUnknownProfileType, t)
+	}
+	if t == TraceType {
+		return "", errors.New("trace is not a pprof profile, it can not be kept")
+	}
+	return client.keptDir(t.String(), "", labelFilters)
+}
+
+// keptDir will return the directory of the profiles kept for the agent with the type, sample type and label filters.
+// Profiles of the same directory can be merged
+func (client *Client) keptDir(profileType, sampleType string, labelFilters map[string]string) (string, error) {
+	if client.keepDir == "" {
+		return "", errors.New("no keep directory is set, see WithKeepDir()")
+	}
+	variant := url.Values{}
+	if sampleType != "" {
+		variant.Set("sample-type", sampleType)
+	}
+	for key, value := range labelFilters {
+		variant.Set("label."+key, value)
+	}
+	name := "all"
+	if len(variant) != 0 {
+		name = variant.Encode()
+	}
+	return filepath.Join(client.keepDir, url.QueryEscape(client.serverAddress), profileType, name), nil
+}
+
+// keep will save content in dir and write the merge of all the profiles kept in dir to writer. Concurrent calls of the
+// client are serialized, so every profile gets its own file and is merged complete
+func (client *Client) keep(dir string, content []byte, writer io.Writer) error {
+	client.keepMutex.Lock()
+	defer client.keepMutex.Unlock()
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(dir, time.Now().UTC().Format(keptNameLayout)+".pb.gz")
+	for _, err = os.Stat(name); err == nil; _, err = os.Stat(name) {
+		name = filepath.Join(dir, time.Now().UTC().Format(keptNameLayout)+".pb.gz")
+	}
+	err = ioutil.WriteFile(name, content, 0600)
+	if err != nil {
+		return err
+	}
+	return mergeKept(dir, writer)
+}
+
+func (client *Client) download(ctx context.Context, dir string, writer io.Writer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	client.keepMutex.Lock()
+	defer client.keepMutex.Unlock()
+	return mergeKept(dir, writer)
+}
+
+// mergeKept will write the merge of the profiles kept in dir to writer
+func mergeKept(dir string, writer io.Writer) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pb.gz"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("%w in %s", ErrNothingKept, dir)
+	}
+	profiles := make([]*pprofile.Profile, 0, len(files))
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		p, err := pprofile.ParseData(content)
+		if err != nil {
+			return fmt.Errorf("kept profile %s: %w", file, err)
+		}
+		profiles = append(profiles, p)
+	}
+	merged, err := pprofile.Merge(profiles)
+	if err != nil {
+		return err
+	}
+	return merged.Write(writer)
+}
@@ -0,0 +1,89 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestKeepAndDownload(t *testing.T) {
+	_, client := newTestClient(t)
+	dir := t.TempDir()
+	if err := WithKeepDir(dir)(client); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	err := client.DownloadLookupProfile(ctx, HeapType, &buf)
+	if !errors.Is(err, ErrNothingKept) {
+		t.Fatalf("download before keeping: got %v, want %v", err, ErrNothingKept)
+	}
+
+	// Concurrent keeps of the same client get a file each
+	const keeps = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, keeps)
+	for i := 0; i < keeps; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var merged bytes.Buffer
+			_, err := client.KeepLookupProfile(ctx, HeapType, &merged)
+			if err == nil {
+				_, err = pprofile.Parse(&merged)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := filepath.Glob(filepath.Join(dir, url.QueryEscape(client.serverAddress), "heap", "all", "*.pb.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != keeps {
+		t.Errorf("kept heap profiles: got %d, want %d", len(files), keeps)
+	}
+
+	buf.Reset()
+	err = client.DownloadLookupProfile(ctx, HeapType, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pprofile.Parse(&buf); err != nil {
+		t.Errorf("downloaded heap profile: %v", err)
+	}
+	err = client.DownloadLookupProfile(ctx, HeapType, &buf, LookupSampleType("inuse_space"))
+	if !errors.Is(err, ErrNothingKept) {
+		t.Errorf("download of another sample type: got %v, want %v", err, ErrNothingKept)
+	}
+
+	err = client.KeepNonLookupProfile(ctx, CPUType, 100*time.Millisecond, nil, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	err = client.DownloadNonLookupProfile(ctx, CPUType, nil, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pprofile.Parse(&buf); err != nil {
+		t.Errorf("downloaded CPU profile: %v", err)
+	}
+	if err = client.KeepNonLookupProfile(ctx, TraceType, 100*time.Millisecond, nil, &buf); err == nil {
+		t.Error("keeping a trace: got no error")
+	}
+}
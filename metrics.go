@@ -0,0 +1,57 @@
+package profile
+
+import (
+	"context"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// MetricKind is type of the value of a runtime metric
+type MetricKind int
+
+// Kinds of runtime metric values
+const (
+	MetricKindUint64 MetricKind = iota
+	MetricKindFloat64
+	MetricKindFloat64Histogram
+)
+
+// Histogram will store the distribution of a runtime metric. Counts[i] is the number of samples in the bucket
+// [Buckets[i], Buckets[i+1])
+type Histogram struct {
+	Counts  []uint64
+	Buckets []float64
+}
+
+// RuntimeMetric will store the value of a runtime metric. Only the field matching Kind is set
+type RuntimeMetric struct {
+	Kind      MetricKind
+	Uint64    uint64
+	Float64   float64
+	Histogram *Histogram
+}
+
+// GetRuntimeMetrics function will get the `runtime/metrics` samples of the agent by metric name, e.g.
+// "/gc/heap/allocs:bytes"
+func (client *Client) GetRuntimeMetrics(ctx context.Context) (map[string]RuntimeMetric, error) {
+	result, err := client.service().GetRuntimeMetrics(ctx, &empty.Empty{}, client.callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	runtimeMetrics := make(map[string]RuntimeMetric, len(result.Metrics))
+	for _, metric := range result.Metrics {
+		switch metric.Kind {
+		case proto.RuntimeMetricKind_metricKindUint64:
+			runtimeMetrics[metric.Name] = RuntimeMetric{Kind: MetricKindUint64, Uint64: metric.Uint64}
+		case proto.RuntimeMetricKind_metricKindFloat64:
+			runtimeMetrics[metric.Name] = RuntimeMetric{Kind: MetricKindFloat64, Float64: metric.Float64}
+		case proto.RuntimeMetricKind_metricKindFloat64Histogram:
+			runtimeMetrics[metric.Name] = RuntimeMetric{
+				Kind:      MetricKindFloat64Histogram,
+				Histogram: &Histogram{Counts: metric.Counts, Buckets: metric.Buckets},
+			}
+		}
+	}
+	return runtimeMetrics, nil
+}
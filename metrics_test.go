@@ -0,0 +1,29 @@
+package profile
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetRuntimeMetrics(t *testing.T) {
+	_, client := newTestClient(t)
+
+	metrics, err := client.GetRuntimeMetrics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocs, ok := metrics["/gc/heap/allocs:bytes"]
+	if !ok {
+		t.Fatal("no /gc/heap/allocs:bytes metric")
+	}
+	if allocs.Kind != MetricKindUint64 || allocs.Uint64 == 0 {
+		t.Errorf("/gc/heap/allocs:bytes: got %+v, want a positive uint64", allocs)
+	}
+	latencies, ok := metrics["/sched/latencies:seconds"]
+	if !ok {
+		t.Fatal("no /sched/latencies:seconds metric")
+	}
+	if latencies.Kind != MetricKindFloat64Histogram || latencies.Histogram == nil || len(latencies.Histogram.Buckets) != len(latencies.Histogram.Counts)+1 {
+		t.Errorf("/sched/latencies:seconds: got %+v, want a histogram with one more bucket boundary than counts", latencies)
+	}
+}
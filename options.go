@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"context"
+	"time"
+)
+
+// ClientOption will configure a GRPC Profile Client created with `Dial()`. An error returned by an option is returned
+// by `Dial()`
+type ClientOption func(*Client) error
+
+// Dial function will create a GRPC Profile Client instance configured with options and connect it to the agent at
+// serverAddress. Without a transport security option the connection is insecure
+func Dial(ctx context.Context, serverAddress string, options ...ClientOption) (*Client, error) {
+	client := &Client{profileSlack: defaultProfileSlack}
+	for _, option := range options {
+		if err := option(client); err != nil {
+			return nil, err
+		}
+	}
+	if !client.transportSet {
+		_ = client.SetDialOption(DialAuthTypeInsecure()) // Default insecure security
+	}
+
+	if err := client.Connect(ctx, serverAddress); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// WithDialOption function will create a `ClientOption` setting a `DialOption`
+func WithDialOption(option *DialOption) ClientOption {
+	return func(client *Client) error {
+		return client.SetDialOption(option)
+	}
+}
+
+// WithCallOption function will create a `ClientOption` setting a `CallOption`
+func WithCallOption(option *CallOption) ClientOption {
+	return func(client *Client) error {
+		return client.SetCallOption(option)
+	}
+}
+
+// WithInsecure function will create a `ClientOption` connecting without transport security
+func WithInsecure() ClientOption {
+	return WithDialOption(DialAuthTypeInsecure())
+}
+
+// WithTLS function will create a `ClientOption` connecting over TLS, see `DialAuthTypeTLS()`
+func WithTLS(certFile string, options ...TLSOption) ClientOption {
+	return WithDialOption(DialAuthTypeTLS(certFile, options...))
+}
+
+// WithMutualTLS function will create a `ClientOption` connecting over mutual TLS, see `DialAuthTypeMutualTLS()`
+func WithMutualTLS(certFile, keyFile, caFile string, options ...TLSOption) ClientOption {
+	return WithDialOption(DialAuthTypeMutualTLS(certFile, keyFile, caFile, options...))
+}
+
+// WithToken function will create a `ClientOption` sending token with every RPC, see `DialAuthTypeToken()`
+func WithToken(token string) ClientOption {
+	return WithDialOption(DialAuthTypeToken(token))
+}
+
+// WithTimeout function will create a `ClientOption` waiting up to d for the connection, see `DialTimeout()`
+func WithTimeout(d time.Duration) ClientOption {
+	return WithDialOption(DialTimeout(d))
+}
@@ -0,0 +1,45 @@
+package profile
+
+import (
+	"context"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+)
+
+type progressKey struct{}
+
+// WithProgress function will return a context making `LookupProfile()`, `NonLookupProfile()`, `BinaryDump()` and their
+// variants called with it report their progress to fn. fn is called after every chunk received from the agent with the
+// number of bytes received so far, so the last call reports the total. With `WithCompression()` the compressed bytes
+// are counted
+func WithProgress(ctx context.Context, fn func(bytesReceived int64)) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func progressFromContext(ctx context.Context) func(int64) {
+	fn, _ := ctx.Value(progressKey{}).(func(int64))
+	return fn
+}
+
+// fileChunkStream is a stream of file chunks received from the agent
+type fileChunkStream interface {
+	Recv() (*proto.FileChunk, error)
+	Context() context.Context
+}
+
+// progressStream will report the bytes received from stream to progress
+type progressStream struct {
+	fileChunkStream
+	received int64
+	progress func(int64)
+}
+
+func (stream *progressStream) Recv() (*proto.FileChunk, error) {
+	fc, err := stream.fileChunkStream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	stream.received += int64(len(fc.Content))
+	stream.progress(stream.received)
+	return fc, nil
+}
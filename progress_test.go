@@ -0,0 +1,46 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestWithProgress(t *testing.T) {
+	var reported []int64
+	ctx := WithProgress(context.Background(), func(bytesReceived int64) {
+		reported = append(reported, bytesReceived)
+	})
+
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var buf bytes.Buffer
+	err := receiveFileChunk(&buf, &sliceStream{content: content, chunkSize: 1024, ctx: ctx}, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (len(content) + 1023) / 1024; len(reported) != want {
+		t.Errorf("progress calls: got %d, want one per chunk, %d", len(reported), want)
+	}
+	for i := 1; i < len(reported); i++ {
+		if reported[i] <= reported[i-1] {
+			t.Fatalf("progress: got %v, want it increasing", reported)
+		}
+	}
+	if len(reported) == 0 || reported[len(reported)-1] != int64(len(content)) {
+		t.Errorf("final progress: got %v, want %d", reported, len(content))
+	}
+
+	// The final progress of a profile is the number of bytes written
+	_, client := newTestClient(t)
+	var last int64
+	ctx = WithProgress(context.Background(), func(bytesReceived int64) {
+		last = bytesReceived
+	})
+	n, _, err := client.WriteLookupProfile(ctx, HeapType, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last != n {
+		t.Errorf("final progress of the heap profile: got %d, want %d", last, n)
+	}
+}
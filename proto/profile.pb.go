@@ -39,6 +39,7 @@ const (
 	ProfileVariable_CPUProfileRate       ProfileVariable = 1
 	ProfileVariable_MutexProfileFraction ProfileVariable = 2
 	ProfileVariable_BlockProfileRate     ProfileVariable = 3
+	ProfileVariable_GCPercent            ProfileVariable = 4
 )
 
 // Enum value maps for ProfileVariable.
@@ -48,12 +49,14 @@ var (
 		1: "CPUProfileRate",
 		2: "MutexProfileFraction",
 		3: "BlockProfileRate",
+		4: "GCPercent",
 	}
 	ProfileVariable_value = map[string]int32{
 		"MemProfileRate":       0,
 		"CPUProfileRate":       1,
 		"MutexProfileFraction": 2,
 		"BlockProfileRate":     3,
+		"GCPercent":            4,
 	}
 )
 
@@ -92,6 +95,7 @@ const (
 	LookupProfile_profileTypeBlock        LookupProfile = 2
 	LookupProfile_profileTypeThreadCreate LookupProfile = 3
 	LookupProfile_profileTypeGoRoutine    LookupProfile = 4
+	LookupProfile_profileTypeAllocs       LookupProfile = 5
 )
 
 // Enum value maps for LookupProfile.
@@ -102,6 +106,7 @@ var (
 		2: "profileTypeBlock",
 		3: "profileTypeThreadCreate",
 		4: "profileTypeGoRoutine",
+		5: "profileTypeAllocs",
 	}
 	LookupProfile_value = map[string]int32{
 		"profileTypeHeap":         0,
@@ -109,6 +114,7 @@ var (
 		"profileTypeBlock":        2,
 		"profileTypeThreadCreate": 3,
 		"profileTypeGoRoutine":    4,
+		"profileTypeAllocs":       5,
 	}
 )
 
@@ -185,18 +191,151 @@ func (NonLookupProfile) EnumDescriptor() ([]byte, []int) {
 	return file_profile_proto_rawDescGZIP(), []int{2}
 }
 
+type RuntimeMetricKind int32
+
+const (
+	RuntimeMetricKind_metricKindBad              RuntimeMetricKind = 0
+	RuntimeMetricKind_metricKindUint64           RuntimeMetricKind = 1
+	RuntimeMetricKind_metricKindFloat64          RuntimeMetricKind = 2
+	RuntimeMetricKind_metricKindFloat64Histogram RuntimeMetricKind = 3
+)
+
+// Enum value maps for RuntimeMetricKind.
+var (
+	RuntimeMetricKind_name = map[int32]string{
+		0: "metricKindBad",
+		1: "metricKindUint64",
+		2: "metricKindFloat64",
+		3: "metricKindFloat64Histogram",
+	}
+	RuntimeMetricKind_value = map[string]int32{
+		"metricKindBad":              0,
+		"metricKindUint64":           1,
+		"metricKindFloat64":          2,
+		"metricKindFloat64Histogram": 3,
+	}
+)
+
+func (x RuntimeMetricKind) Enum() *RuntimeMetricKind {
+	p := new(RuntimeMetricKind)
+	*p = x
+	return p
+}
+
+func (x RuntimeMetricKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RuntimeMetricKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_profile_proto_enumTypes[3].Descriptor()
+}
+
+func (RuntimeMetricKind) Type() protoreflect.EnumType {
+	return &file_profile_proto_enumTypes[3]
+}
+
+func (x RuntimeMetricKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RuntimeMetricKind.Descriptor instead.
+func (RuntimeMetricKind) EnumDescriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{3}
+}
+
+type ProfileMeta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProfileType string               `protobuf:"bytes,1,opt,name=ProfileType,proto3" json:"ProfileType,omitempty"`
+	Time        *timestamp.Timestamp `protobuf:"bytes,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	Duration    *duration.Duration   `protobuf:"bytes,3,opt,name=Duration,proto3" json:"Duration,omitempty"`
+	Hostname    string               `protobuf:"bytes,4,opt,name=Hostname,proto3" json:"Hostname,omitempty"`
+	GoVersion   string               `protobuf:"bytes,5,opt,name=GoVersion,proto3" json:"GoVersion,omitempty"`
+}
+
+func (x *ProfileMeta) Reset() {
+	*x = ProfileMeta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProfileMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileMeta) ProtoMessage() {}
+
+func (x *ProfileMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileMeta.ProtoReflect.Descriptor instead.
+func (*ProfileMeta) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProfileMeta) GetProfileType() string {
+	if x != nil {
+		return x.ProfileType
+	}
+	return ""
+}
+
+func (x *ProfileMeta) GetTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ProfileMeta) GetDuration() *duration.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ProfileMeta) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *ProfileMeta) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
 type FileChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Content []byte `protobuf:"bytes,1,opt,name=Content,proto3" json:"Content,omitempty"`
+	// Meta is only set on the first chunk of a profile, which carries no content
+	Meta *ProfileMeta `protobuf:"bytes,2,opt,name=Meta,proto3" json:"Meta,omitempty"`
 }
 
 func (x *FileChunk) Reset() {
 	*x = FileChunk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[0]
+		mi := &file_profile_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -209,7 +348,7 @@ func (x *FileChunk) String() string {
 func (*FileChunk) ProtoMessage() {}
 
 func (x *FileChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[0]
+	mi := &file_profile_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +361,7 @@ func (x *FileChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
 func (*FileChunk) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{0}
+	return file_profile_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *FileChunk) GetContent() []byte {
@@ -232,6 +371,13 @@ func (x *FileChunk) GetContent() []byte {
 	return nil
 }
 
+func (x *FileChunk) GetMeta() *ProfileMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
 type StringType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -243,7 +389,7 @@ type StringType struct {
 func (x *StringType) Reset() {
 	*x = StringType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[1]
+		mi := &file_profile_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -256,7 +402,7 @@ func (x *StringType) String() string {
 func (*StringType) ProtoMessage() {}
 
 func (x *StringType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[1]
+	mi := &file_profile_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,7 +415,7 @@ func (x *StringType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StringType.ProtoReflect.Descriptor instead.
 func (*StringType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{1}
+	return file_profile_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *StringType) GetMessage() string {
@@ -290,7 +436,7 @@ type IntType struct {
 func (x *IntType) Reset() {
 	*x = IntType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[2]
+		mi := &file_profile_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -303,7 +449,7 @@ func (x *IntType) String() string {
 func (*IntType) ProtoMessage() {}
 
 func (x *IntType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[2]
+	mi := &file_profile_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +462,7 @@ func (x *IntType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntType.ProtoReflect.Descriptor instead.
 func (*IntType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{2}
+	return file_profile_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *IntType) GetValue() int32 {
@@ -337,7 +483,7 @@ type LookupProfileType struct {
 func (x *LookupProfileType) Reset() {
 	*x = LookupProfileType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[3]
+		mi := &file_profile_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -350,7 +496,7 @@ func (x *LookupProfileType) String() string {
 func (*LookupProfileType) ProtoMessage() {}
 
 func (x *LookupProfileType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[3]
+	mi := &file_profile_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,7 +509,7 @@ func (x *LookupProfileType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookupProfileType.ProtoReflect.Descriptor instead.
 func (*LookupProfileType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{3}
+	return file_profile_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *LookupProfileType) GetProfile() LookupProfile {
@@ -384,7 +530,7 @@ type NonLookupProfileType struct {
 func (x *NonLookupProfileType) Reset() {
 	*x = NonLookupProfileType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[4]
+		mi := &file_profile_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -397,7 +543,7 @@ func (x *NonLookupProfileType) String() string {
 func (*NonLookupProfileType) ProtoMessage() {}
 
 func (x *NonLookupProfileType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[4]
+	mi := &file_profile_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +556,7 @@ func (x *NonLookupProfileType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NonLookupProfileType.ProtoReflect.Descriptor instead.
 func (*NonLookupProfileType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{4}
+	return file_profile_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NonLookupProfileType) GetProfile() NonLookupProfile {
@@ -432,7 +578,7 @@ type SetProfileInputType struct {
 func (x *SetProfileInputType) Reset() {
 	*x = SetProfileInputType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[5]
+		mi := &file_profile_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -445,7 +591,7 @@ func (x *SetProfileInputType) String() string {
 func (*SetProfileInputType) ProtoMessage() {}
 
 func (x *SetProfileInputType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[5]
+	mi := &file_profile_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,7 +604,7 @@ func (x *SetProfileInputType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetProfileInputType.ProtoReflect.Descriptor instead.
 func (*SetProfileInputType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{5}
+	return file_profile_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SetProfileInputType) GetVariable() ProfileVariable {
@@ -475,7 +621,7 @@ func (x *SetProfileInputType) GetRate() int32 {
 	return 0
 }
 
-type ResetProfileInputType struct {
+type GetProfileInputType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -483,23 +629,23 @@ type ResetProfileInputType struct {
 	Variable ProfileVariable `protobuf:"varint,1,opt,name=Variable,proto3,enum=proto.ProfileVariable" json:"Variable,omitempty"`
 }
 
-func (x *ResetProfileInputType) Reset() {
-	*x = ResetProfileInputType{}
+func (x *GetProfileInputType) Reset() {
+	*x = GetProfileInputType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[6]
+		mi := &file_profile_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResetProfileInputType) String() string {
+func (x *GetProfileInputType) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResetProfileInputType) ProtoMessage() {}
+func (*GetProfileInputType) ProtoMessage() {}
 
-func (x *ResetProfileInputType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[6]
+func (x *GetProfileInputType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,43 +656,43 @@ func (x *ResetProfileInputType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResetProfileInputType.ProtoReflect.Descriptor instead.
-func (*ResetProfileInputType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetProfileInputType.ProtoReflect.Descriptor instead.
+func (*GetProfileInputType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ResetProfileInputType) GetVariable() ProfileVariable {
+func (x *GetProfileInputType) GetVariable() ProfileVariable {
 	if x != nil {
 		return x.Variable
 	}
 	return ProfileVariable_MemProfileRate
 }
 
-type LookupProfileInputType struct {
+type ResetProfileInputType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProfileType LookupProfile `protobuf:"varint,1,opt,name=ProfileType,proto3,enum=proto.LookupProfile" json:"ProfileType,omitempty"`
+	Variable ProfileVariable `protobuf:"varint,1,opt,name=Variable,proto3,enum=proto.ProfileVariable" json:"Variable,omitempty"`
 }
 
-func (x *LookupProfileInputType) Reset() {
-	*x = LookupProfileInputType{}
+func (x *ResetProfileInputType) Reset() {
+	*x = ResetProfileInputType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[7]
+		mi := &file_profile_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LookupProfileInputType) String() string {
+func (x *ResetProfileInputType) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LookupProfileInputType) ProtoMessage() {}
+func (*ResetProfileInputType) ProtoMessage() {}
 
-func (x *LookupProfileInputType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[7]
+func (x *ResetProfileInputType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,44 +703,44 @@ func (x *LookupProfileInputType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LookupProfileInputType.ProtoReflect.Descriptor instead.
-func (*LookupProfileInputType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ResetProfileInputType.ProtoReflect.Descriptor instead.
+func (*ResetProfileInputType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *LookupProfileInputType) GetProfileType() LookupProfile {
+func (x *ResetProfileInputType) GetVariable() ProfileVariable {
 	if x != nil {
-		return x.ProfileType
+		return x.Variable
 	}
-	return LookupProfile_profileTypeHeap
+	return ProfileVariable_MemProfileRate
 }
 
-type NonLookupProfileInputType struct {
+type VariableValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProfileType NonLookupProfile   `protobuf:"varint,1,opt,name=ProfileType,proto3,enum=proto.NonLookupProfile" json:"ProfileType,omitempty"`
-	Duration    *duration.Duration `protobuf:"bytes,2,opt,name=Duration,proto3" json:"Duration,omitempty"`
+	Variable ProfileVariable `protobuf:"varint,1,opt,name=Variable,proto3,enum=proto.ProfileVariable" json:"Variable,omitempty"`
+	Value    int32           `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
 }
 
-func (x *NonLookupProfileInputType) Reset() {
-	*x = NonLookupProfileInputType{}
+func (x *VariableValue) Reset() {
+	*x = VariableValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[8]
+		mi := &file_profile_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NonLookupProfileInputType) String() string {
+func (x *VariableValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NonLookupProfileInputType) ProtoMessage() {}
+func (*VariableValue) ProtoMessage() {}
 
-func (x *NonLookupProfileInputType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[8]
+func (x *VariableValue) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,76 +751,50 @@ func (x *NonLookupProfileInputType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NonLookupProfileInputType.ProtoReflect.Descriptor instead.
-func (*NonLookupProfileInputType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use VariableValue.ProtoReflect.Descriptor instead.
+func (*VariableValue) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *NonLookupProfileInputType) GetProfileType() NonLookupProfile {
+func (x *VariableValue) GetVariable() ProfileVariable {
 	if x != nil {
-		return x.ProfileType
+		return x.Variable
 	}
-	return NonLookupProfile_profileTypeCPU
+	return ProfileVariable_MemProfileRate
 }
 
-func (x *NonLookupProfileInputType) GetDuration() *duration.Duration {
+func (x *VariableValue) GetValue() int32 {
 	if x != nil {
-		return x.Duration
+		return x.Value
 	}
-	return nil
+	return 0
 }
 
-type MemStats struct {
+type VariablesType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Alloc        uint64               `protobuf:"varint,1,opt,name=Alloc,proto3" json:"Alloc,omitempty"`
-	TotalAlloc   uint64               `protobuf:"varint,2,opt,name=TotalAlloc,proto3" json:"TotalAlloc,omitempty"`
-	Sys          uint64               `protobuf:"varint,3,opt,name=Sys,proto3" json:"Sys,omitempty"`
-	Lookups      uint64               `protobuf:"varint,4,opt,name=Lookups,proto3" json:"Lookups,omitempty"`
-	Mallocs      uint64               `protobuf:"varint,5,opt,name=Mallocs,proto3" json:"Mallocs,omitempty"`
-	Frees        uint64               `protobuf:"varint,6,opt,name=Frees,proto3" json:"Frees,omitempty"`
-	HeapAlloc    uint64               `protobuf:"varint,7,opt,name=HeapAlloc,proto3" json:"HeapAlloc,omitempty"`
-	HeapSys      uint64               `protobuf:"varint,8,opt,name=HeapSys,proto3" json:"HeapSys,omitempty"`
-	HeapIdle     uint64               `protobuf:"varint,9,opt,name=HeapIdle,proto3" json:"HeapIdle,omitempty"`
-	HeapInuse    uint64               `protobuf:"varint,10,opt,name=HeapInuse,proto3" json:"HeapInuse,omitempty"`
-	HeapReleased uint64               `protobuf:"varint,11,opt,name=HeapReleased,proto3" json:"HeapReleased,omitempty"`
-	HeapObjects  uint64               `protobuf:"varint,12,opt,name=HeapObjects,proto3" json:"HeapObjects,omitempty"`
-	StackInuse   uint64               `protobuf:"varint,13,opt,name=StackInuse,proto3" json:"StackInuse,omitempty"`
-	StackSys     uint64               `protobuf:"varint,14,opt,name=StackSys,proto3" json:"StackSys,omitempty"`
-	MSpanInuse   uint64               `protobuf:"varint,15,opt,name=MSpanInuse,proto3" json:"MSpanInuse,omitempty"`
-	MSpanSys     uint64               `protobuf:"varint,16,opt,name=MSpanSys,proto3" json:"MSpanSys,omitempty"`
-	MCacheInuse  uint64               `protobuf:"varint,17,opt,name=MCacheInuse,proto3" json:"MCacheInuse,omitempty"`
-	MCacheSys    uint64               `protobuf:"varint,18,opt,name=MCacheSys,proto3" json:"MCacheSys,omitempty"`
-	BuckHashSys  uint64               `protobuf:"varint,19,opt,name=BuckHashSys,proto3" json:"BuckHashSys,omitempty"`
-	GCSys        uint64               `protobuf:"varint,20,opt,name=GCSys,proto3" json:"GCSys,omitempty"`
-	OtherSys     uint64               `protobuf:"varint,21,opt,name=OtherSys,proto3" json:"OtherSys,omitempty"`
-	NextGC       uint64               `protobuf:"varint,22,opt,name=NextGC,proto3" json:"NextGC,omitempty"`
-	LastGC       *timestamp.Timestamp `protobuf:"bytes,23,opt,name=LastGC,proto3" json:"LastGC,omitempty"`
-	PauseTotalNs *duration.Duration   `protobuf:"bytes,24,opt,name=PauseTotalNs,proto3" json:"PauseTotalNs,omitempty"`
-	LastPause    *timestamp.Timestamp `protobuf:"bytes,25,opt,name=LastPause,proto3" json:"LastPause,omitempty"`
-	NumGC        uint32               `protobuf:"varint,26,opt,name=NumGC,proto3" json:"NumGC,omitempty"`
-	NumForcedGC  uint32               `protobuf:"varint,27,opt,name=NumForcedGC,proto3" json:"NumForcedGC,omitempty"`
+	Values []*VariableValue `protobuf:"bytes,1,rep,name=Values,proto3" json:"Values,omitempty"`
 }
 
-func (x *MemStats) Reset() {
-	*x = MemStats{}
+func (x *VariablesType) Reset() {
+	*x = VariablesType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[9]
+		mi := &file_profile_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MemStats) String() string {
+func (x *VariablesType) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MemStats) ProtoMessage() {}
+func (*VariablesType) ProtoMessage() {}
 
-func (x *MemStats) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[9]
+func (x *VariablesType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -685,228 +805,269 @@ func (x *MemStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MemStats.ProtoReflect.Descriptor instead.
-func (*MemStats) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use VariablesType.ProtoReflect.Descriptor instead.
+func (*VariablesType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *MemStats) GetAlloc() uint64 {
+func (x *VariablesType) GetValues() []*VariableValue {
 	if x != nil {
-		return x.Alloc
+		return x.Values
 	}
-	return 0
+	return nil
 }
 
-func (x *MemStats) GetTotalAlloc() uint64 {
-	if x != nil {
-		return x.TotalAlloc
-	}
-	return 0
+type LookupProfileInputType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProfileType LookupProfile `protobuf:"varint,1,opt,name=ProfileType,proto3,enum=proto.LookupProfile" json:"ProfileType,omitempty"`
+	Debug       int32         `protobuf:"varint,2,opt,name=Debug,proto3" json:"Debug,omitempty"`
+	Compress    bool          `protobuf:"varint,3,opt,name=Compress,proto3" json:"Compress,omitempty"`
+	SampleType  string        `protobuf:"bytes,4,opt,name=SampleType,proto3" json:"SampleType,omitempty"`
+	GCBefore    bool          `protobuf:"varint,5,opt,name=GCBefore,proto3" json:"GCBefore,omitempty"`
+	// LabelFilters keeps only the samples with all of the pprof labels, it can not be combined with Debug
+	LabelFilters map[string]string `protobuf:"bytes,6,rep,name=LabelFilters,proto3" json:"LabelFilters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *MemStats) GetSys() uint64 {
-	if x != nil {
-		return x.Sys
+func (x *LookupProfileInputType) Reset() {
+	*x = LookupProfileInputType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *MemStats) GetLookups() uint64 {
-	if x != nil {
-		return x.Lookups
-	}
-	return 0
+func (x *LookupProfileInputType) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *MemStats) GetMallocs() uint64 {
-	if x != nil {
-		return x.Mallocs
+func (*LookupProfileInputType) ProtoMessage() {}
+
+func (x *LookupProfileInputType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *MemStats) GetFrees() uint64 {
-	if x != nil {
-		return x.Frees
-	}
-	return 0
+// Deprecated: Use LookupProfileInputType.ProtoReflect.Descriptor instead.
+func (*LookupProfileInputType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *MemStats) GetHeapAlloc() uint64 {
+func (x *LookupProfileInputType) GetProfileType() LookupProfile {
 	if x != nil {
-		return x.HeapAlloc
+		return x.ProfileType
 	}
-	return 0
+	return LookupProfile_profileTypeHeap
 }
 
-func (x *MemStats) GetHeapSys() uint64 {
+func (x *LookupProfileInputType) GetDebug() int32 {
 	if x != nil {
-		return x.HeapSys
+		return x.Debug
 	}
 	return 0
 }
 
-func (x *MemStats) GetHeapIdle() uint64 {
+func (x *LookupProfileInputType) GetCompress() bool {
 	if x != nil {
-		return x.HeapIdle
+		return x.Compress
 	}
-	return 0
+	return false
 }
 
-func (x *MemStats) GetHeapInuse() uint64 {
+func (x *LookupProfileInputType) GetSampleType() string {
 	if x != nil {
-		return x.HeapInuse
+		return x.SampleType
 	}
-	return 0
+	return ""
 }
 
-func (x *MemStats) GetHeapReleased() uint64 {
+func (x *LookupProfileInputType) GetGCBefore() bool {
 	if x != nil {
-		return x.HeapReleased
+		return x.GCBefore
 	}
-	return 0
+	return false
 }
 
-func (x *MemStats) GetHeapObjects() uint64 {
+func (x *LookupProfileInputType) GetLabelFilters() map[string]string {
 	if x != nil {
-		return x.HeapObjects
+		return x.LabelFilters
 	}
-	return 0
+	return nil
 }
 
-func (x *MemStats) GetStackInuse() uint64 {
-	if x != nil {
-		return x.StackInuse
-	}
-	return 0
+type NonLookupProfileInputType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProfileType NonLookupProfile   `protobuf:"varint,1,opt,name=ProfileType,proto3,enum=proto.NonLookupProfile" json:"ProfileType,omitempty"`
+	Duration    *duration.Duration `protobuf:"bytes,2,opt,name=Duration,proto3" json:"Duration,omitempty"`
+	Compress    bool               `protobuf:"varint,3,opt,name=Compress,proto3" json:"Compress,omitempty"`
+	// LabelFilters keeps only the samples with all of the pprof labels, it can not be used for traces
+	LabelFilters map[string]string `protobuf:"bytes,4,rep,name=LabelFilters,proto3" json:"LabelFilters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *MemStats) GetStackSys() uint64 {
-	if x != nil {
-		return x.StackSys
+func (x *NonLookupProfileInputType) Reset() {
+	*x = NonLookupProfileInputType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *MemStats) GetMSpanInuse() uint64 {
-	if x != nil {
-		return x.MSpanInuse
-	}
-	return 0
+func (x *NonLookupProfileInputType) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *MemStats) GetMSpanSys() uint64 {
-	if x != nil {
-		return x.MSpanSys
+func (*NonLookupProfileInputType) ProtoMessage() {}
+
+func (x *NonLookupProfileInputType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *MemStats) GetMCacheInuse() uint64 {
-	if x != nil {
-		return x.MCacheInuse
-	}
-	return 0
+// Deprecated: Use NonLookupProfileInputType.ProtoReflect.Descriptor instead.
+func (*NonLookupProfileInputType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *MemStats) GetMCacheSys() uint64 {
+func (x *NonLookupProfileInputType) GetProfileType() NonLookupProfile {
 	if x != nil {
-		return x.MCacheSys
+		return x.ProfileType
 	}
-	return 0
+	return NonLookupProfile_profileTypeCPU
 }
 
-func (x *MemStats) GetBuckHashSys() uint64 {
+func (x *NonLookupProfileInputType) GetDuration() *duration.Duration {
 	if x != nil {
-		return x.BuckHashSys
+		return x.Duration
 	}
-	return 0
+	return nil
 }
 
-func (x *MemStats) GetGCSys() uint64 {
+func (x *NonLookupProfileInputType) GetCompress() bool {
 	if x != nil {
-		return x.GCSys
+		return x.Compress
 	}
-	return 0
+	return false
 }
 
-func (x *MemStats) GetOtherSys() uint64 {
+func (x *NonLookupProfileInputType) GetLabelFilters() map[string]string {
 	if x != nil {
-		return x.OtherSys
+		return x.LabelFilters
 	}
-	return 0
+	return nil
 }
 
-func (x *MemStats) GetNextGC() uint64 {
-	if x != nil {
-		return x.NextGC
-	}
-	return 0
+type ContinuousProfileInputType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProfileType NonLookupProfile   `protobuf:"varint,1,opt,name=ProfileType,proto3,enum=proto.NonLookupProfile" json:"ProfileType,omitempty"`
+	Interval    *duration.Duration `protobuf:"bytes,2,opt,name=Interval,proto3" json:"Interval,omitempty"`
+	// Compress gzip compresses every profile, the Length of its frames is the compressed length
+	Compress bool `protobuf:"varint,3,opt,name=Compress,proto3" json:"Compress,omitempty"`
 }
 
-func (x *MemStats) GetLastGC() *timestamp.Timestamp {
-	if x != nil {
-		return x.LastGC
+func (x *ContinuousProfileInputType) Reset() {
+	*x = ContinuousProfileInputType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *MemStats) GetPauseTotalNs() *duration.Duration {
-	if x != nil {
-		return x.PauseTotalNs
+func (x *ContinuousProfileInputType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContinuousProfileInputType) ProtoMessage() {}
+
+func (x *ContinuousProfileInputType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *MemStats) GetLastPause() *timestamp.Timestamp {
+// Deprecated: Use ContinuousProfileInputType.ProtoReflect.Descriptor instead.
+func (*ContinuousProfileInputType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ContinuousProfileInputType) GetProfileType() NonLookupProfile {
 	if x != nil {
-		return x.LastPause
+		return x.ProfileType
 	}
-	return nil
+	return NonLookupProfile_profileTypeCPU
 }
 
-func (x *MemStats) GetNumGC() uint32 {
+func (x *ContinuousProfileInputType) GetInterval() *duration.Duration {
 	if x != nil {
-		return x.NumGC
+		return x.Interval
 	}
-	return 0
+	return nil
 }
 
-func (x *MemStats) GetNumForcedGC() uint32 {
+func (x *ContinuousProfileInputType) GetCompress() bool {
 	if x != nil {
-		return x.NumForcedGC
+		return x.Compress
 	}
-	return 0
+	return false
 }
 
-type FileInfo struct {
+// SnapshotInputType requests a tar archive with a CPU profile of CPUDuration, a heap profile, a goroutine dump and the
+// information about the agent
+type SnapshotInputType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string               `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Size     int64                `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
-	Mode     uint32               `protobuf:"varint,3,opt,name=Mode,proto3" json:"Mode,omitempty"`
-	ModeTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=ModeTime,proto3" json:"ModeTime,omitempty"`
+	CPUDuration *duration.Duration `protobuf:"bytes,1,opt,name=CPUDuration,proto3" json:"CPUDuration,omitempty"`
 }
 
-func (x *FileInfo) Reset() {
-	*x = FileInfo{}
+func (x *SnapshotInputType) Reset() {
+	*x = SnapshotInputType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[10]
+		mi := &file_profile_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FileInfo) String() string {
+func (x *SnapshotInputType) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileInfo) ProtoMessage() {}
+func (*SnapshotInputType) ProtoMessage() {}
 
-func (x *FileInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[10]
+func (x *SnapshotInputType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -917,65 +1078,45 @@ func (x *FileInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
-func (*FileInfo) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *FileInfo) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *FileInfo) GetSize() int64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
-}
-
-func (x *FileInfo) GetMode() uint32 {
-	if x != nil {
-		return x.Mode
-	}
-	return 0
+// Deprecated: Use SnapshotInputType.ProtoReflect.Descriptor instead.
+func (*SnapshotInputType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *FileInfo) GetModeTime() *timestamp.Timestamp {
+func (x *SnapshotInputType) GetCPUDuration() *duration.Duration {
 	if x != nil {
-		return x.ModeTime
+		return x.CPUDuration
 	}
 	return nil
 }
 
-type IDName struct {
+type ProfileFrame struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID   int32  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Sequence uint32 `protobuf:"varint,1,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+	Length   int64  `protobuf:"varint,2,opt,name=Length,proto3" json:"Length,omitempty"`
+	Content  []byte `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`
 }
 
-func (x *IDName) Reset() {
-	*x = IDName{}
+func (x *ProfileFrame) Reset() {
+	*x = ProfileFrame{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[11]
+		mi := &file_profile_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IDName) String() string {
+func (x *ProfileFrame) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IDName) ProtoMessage() {}
+func (*ProfileFrame) ProtoMessage() {}
 
-func (x *IDName) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[11]
+func (x *ProfileFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -986,42 +1127,430 @@ func (x *IDName) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IDName.ProtoReflect.Descriptor instead.
-func (*IDName) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ProfileFrame.ProtoReflect.Descriptor instead.
+func (*ProfileFrame) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *IDName) GetID() int32 {
+func (x *ProfileFrame) GetSequence() uint32 {
 	if x != nil {
-		return x.ID
+		return x.Sequence
 	}
 	return 0
 }
 
-func (x *IDName) GetName() string {
+func (x *ProfileFrame) GetLength() int64 {
 	if x != nil {
-		return x.Name
+		return x.Length
 	}
-	return ""
+	return 0
 }
 
-type ProcessStats struct {
+func (x *ProfileFrame) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type MemStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Environ         []string  `protobuf:"bytes,1,rep,name=Environ,proto3" json:"Environ,omitempty"`
-	Executable      string    `protobuf:"bytes,2,opt,name=Executable,proto3" json:"Executable,omitempty"`
-	ExecutableLStat *FileInfo `protobuf:"bytes,3,opt,name=ExecutableLStat,proto3" json:"ExecutableLStat,omitempty"`
-	ExecutableStat  *FileInfo `protobuf:"bytes,4,opt,name=ExecutableStat,proto3" json:"ExecutableStat,omitempty"`
-	UID             *IDName   `protobuf:"bytes,5,opt,name=UID,proto3" json:"UID,omitempty"`
-	GID             *IDName   `protobuf:"bytes,6,opt,name=GID,proto3" json:"GID,omitempty"`
-	EUID            *IDName   `protobuf:"bytes,7,opt,name=EUID,proto3" json:"EUID,omitempty"`
-	EGID            *IDName   `protobuf:"bytes,8,opt,name=EGID,proto3" json:"EGID,omitempty"`
-	Groups          []*IDName `protobuf:"bytes,9,rep,name=Groups,proto3" json:"Groups,omitempty"`
-	PageSize        int32     `protobuf:"varint,10,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
-	PID             int32     `protobuf:"varint,11,opt,name=PID,proto3" json:"PID,omitempty"`
-	PPID            int32     `protobuf:"varint,12,opt,name=PPID,proto3" json:"PPID,omitempty"`
+	Alloc        uint64               `protobuf:"varint,1,opt,name=Alloc,proto3" json:"Alloc,omitempty"`
+	TotalAlloc   uint64               `protobuf:"varint,2,opt,name=TotalAlloc,proto3" json:"TotalAlloc,omitempty"`
+	Sys          uint64               `protobuf:"varint,3,opt,name=Sys,proto3" json:"Sys,omitempty"`
+	Lookups      uint64               `protobuf:"varint,4,opt,name=Lookups,proto3" json:"Lookups,omitempty"`
+	Mallocs      uint64               `protobuf:"varint,5,opt,name=Mallocs,proto3" json:"Mallocs,omitempty"`
+	Frees        uint64               `protobuf:"varint,6,opt,name=Frees,proto3" json:"Frees,omitempty"`
+	HeapAlloc    uint64               `protobuf:"varint,7,opt,name=HeapAlloc,proto3" json:"HeapAlloc,omitempty"`
+	HeapSys      uint64               `protobuf:"varint,8,opt,name=HeapSys,proto3" json:"HeapSys,omitempty"`
+	HeapIdle     uint64               `protobuf:"varint,9,opt,name=HeapIdle,proto3" json:"HeapIdle,omitempty"`
+	HeapInuse    uint64               `protobuf:"varint,10,opt,name=HeapInuse,proto3" json:"HeapInuse,omitempty"`
+	HeapReleased uint64               `protobuf:"varint,11,opt,name=HeapReleased,proto3" json:"HeapReleased,omitempty"`
+	HeapObjects  uint64               `protobuf:"varint,12,opt,name=HeapObjects,proto3" json:"HeapObjects,omitempty"`
+	StackInuse   uint64               `protobuf:"varint,13,opt,name=StackInuse,proto3" json:"StackInuse,omitempty"`
+	StackSys     uint64               `protobuf:"varint,14,opt,name=StackSys,proto3" json:"StackSys,omitempty"`
+	MSpanInuse   uint64               `protobuf:"varint,15,opt,name=MSpanInuse,proto3" json:"MSpanInuse,omitempty"`
+	MSpanSys     uint64               `protobuf:"varint,16,opt,name=MSpanSys,proto3" json:"MSpanSys,omitempty"`
+	MCacheInuse  uint64               `protobuf:"varint,17,opt,name=MCacheInuse,proto3" json:"MCacheInuse,omitempty"`
+	MCacheSys    uint64               `protobuf:"varint,18,opt,name=MCacheSys,proto3" json:"MCacheSys,omitempty"`
+	BuckHashSys  uint64               `protobuf:"varint,19,opt,name=BuckHashSys,proto3" json:"BuckHashSys,omitempty"`
+	GCSys        uint64               `protobuf:"varint,20,opt,name=GCSys,proto3" json:"GCSys,omitempty"`
+	OtherSys     uint64               `protobuf:"varint,21,opt,name=OtherSys,proto3" json:"OtherSys,omitempty"`
+	NextGC       uint64               `protobuf:"varint,22,opt,name=NextGC,proto3" json:"NextGC,omitempty"`
+	LastGC       *timestamp.Timestamp `protobuf:"bytes,23,opt,name=LastGC,proto3" json:"LastGC,omitempty"`
+	PauseTotalNs *duration.Duration   `protobuf:"bytes,24,opt,name=PauseTotalNs,proto3" json:"PauseTotalNs,omitempty"`
+	LastPause    *timestamp.Timestamp `protobuf:"bytes,25,opt,name=LastPause,proto3" json:"LastPause,omitempty"`
+	NumGC        uint32               `protobuf:"varint,26,opt,name=NumGC,proto3" json:"NumGC,omitempty"`
+	NumForcedGC  uint32               `protobuf:"varint,27,opt,name=NumForcedGC,proto3" json:"NumForcedGC,omitempty"`
+}
+
+func (x *MemStats) Reset() {
+	*x = MemStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemStats) ProtoMessage() {}
+
+func (x *MemStats) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemStats.ProtoReflect.Descriptor instead.
+func (*MemStats) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MemStats) GetAlloc() uint64 {
+	if x != nil {
+		return x.Alloc
+	}
+	return 0
+}
+
+func (x *MemStats) GetTotalAlloc() uint64 {
+	if x != nil {
+		return x.TotalAlloc
+	}
+	return 0
+}
+
+func (x *MemStats) GetSys() uint64 {
+	if x != nil {
+		return x.Sys
+	}
+	return 0
+}
+
+func (x *MemStats) GetLookups() uint64 {
+	if x != nil {
+		return x.Lookups
+	}
+	return 0
+}
+
+func (x *MemStats) GetMallocs() uint64 {
+	if x != nil {
+		return x.Mallocs
+	}
+	return 0
+}
+
+func (x *MemStats) GetFrees() uint64 {
+	if x != nil {
+		return x.Frees
+	}
+	return 0
+}
+
+func (x *MemStats) GetHeapAlloc() uint64 {
+	if x != nil {
+		return x.HeapAlloc
+	}
+	return 0
+}
+
+func (x *MemStats) GetHeapSys() uint64 {
+	if x != nil {
+		return x.HeapSys
+	}
+	return 0
+}
+
+func (x *MemStats) GetHeapIdle() uint64 {
+	if x != nil {
+		return x.HeapIdle
+	}
+	return 0
+}
+
+func (x *MemStats) GetHeapInuse() uint64 {
+	if x != nil {
+		return x.HeapInuse
+	}
+	return 0
+}
+
+func (x *MemStats) GetHeapReleased() uint64 {
+	if x != nil {
+		return x.HeapReleased
+	}
+	return 0
+}
+
+func (x *MemStats) GetHeapObjects() uint64 {
+	if x != nil {
+		return x.HeapObjects
+	}
+	return 0
+}
+
+func (x *MemStats) GetStackInuse() uint64 {
+	if x != nil {
+		return x.StackInuse
+	}
+	return 0
+}
+
+func (x *MemStats) GetStackSys() uint64 {
+	if x != nil {
+		return x.StackSys
+	}
+	return 0
+}
+
+func (x *MemStats) GetMSpanInuse() uint64 {
+	if x != nil {
+		return x.MSpanInuse
+	}
+	return 0
+}
+
+func (x *MemStats) GetMSpanSys() uint64 {
+	if x != nil {
+		return x.MSpanSys
+	}
+	return 0
+}
+
+func (x *MemStats) GetMCacheInuse() uint64 {
+	if x != nil {
+		return x.MCacheInuse
+	}
+	return 0
+}
+
+func (x *MemStats) GetMCacheSys() uint64 {
+	if x != nil {
+		return x.MCacheSys
+	}
+	return 0
+}
+
+func (x *MemStats) GetBuckHashSys() uint64 {
+	if x != nil {
+		return x.BuckHashSys
+	}
+	return 0
+}
+
+func (x *MemStats) GetGCSys() uint64 {
+	if x != nil {
+		return x.GCSys
+	}
+	return 0
+}
+
+func (x *MemStats) GetOtherSys() uint64 {
+	if x != nil {
+		return x.OtherSys
+	}
+	return 0
+}
+
+func (x *MemStats) GetNextGC() uint64 {
+	if x != nil {
+		return x.NextGC
+	}
+	return 0
+}
+
+func (x *MemStats) GetLastGC() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastGC
+	}
+	return nil
+}
+
+func (x *MemStats) GetPauseTotalNs() *duration.Duration {
+	if x != nil {
+		return x.PauseTotalNs
+	}
+	return nil
+}
+
+func (x *MemStats) GetLastPause() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastPause
+	}
+	return nil
+}
+
+func (x *MemStats) GetNumGC() uint32 {
+	if x != nil {
+		return x.NumGC
+	}
+	return 0
+}
+
+func (x *MemStats) GetNumForcedGC() uint32 {
+	if x != nil {
+		return x.NumForcedGC
+	}
+	return 0
+}
+
+type FileInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string               `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Size     int64                `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
+	Mode     uint32               `protobuf:"varint,3,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	ModeTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=ModeTime,proto3" json:"ModeTime,omitempty"`
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FileInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *FileInfo) GetModeTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.ModeTime
+	}
+	return nil
+}
+
+type IDName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID   int32  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+}
+
+func (x *IDName) Reset() {
+	*x = IDName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IDName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDName) ProtoMessage() {}
+
+func (x *IDName) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDName.ProtoReflect.Descriptor instead.
+func (*IDName) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *IDName) GetID() int32 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+func (x *IDName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ProcessStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Environ         []string  `protobuf:"bytes,1,rep,name=Environ,proto3" json:"Environ,omitempty"`
+	Executable      string    `protobuf:"bytes,2,opt,name=Executable,proto3" json:"Executable,omitempty"`
+	ExecutableLStat *FileInfo `protobuf:"bytes,3,opt,name=ExecutableLStat,proto3" json:"ExecutableLStat,omitempty"`
+	ExecutableStat  *FileInfo `protobuf:"bytes,4,opt,name=ExecutableStat,proto3" json:"ExecutableStat,omitempty"`
+	UID             *IDName   `protobuf:"bytes,5,opt,name=UID,proto3" json:"UID,omitempty"`
+	GID             *IDName   `protobuf:"bytes,6,opt,name=GID,proto3" json:"GID,omitempty"`
+	EUID            *IDName   `protobuf:"bytes,7,opt,name=EUID,proto3" json:"EUID,omitempty"`
+	EGID            *IDName   `protobuf:"bytes,8,opt,name=EGID,proto3" json:"EGID,omitempty"`
+	Groups          []*IDName `protobuf:"bytes,9,rep,name=Groups,proto3" json:"Groups,omitempty"`
+	PageSize        int32     `protobuf:"varint,10,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	PID             int32     `protobuf:"varint,11,opt,name=PID,proto3" json:"PID,omitempty"`
+	PPID            int32     `protobuf:"varint,12,opt,name=PPID,proto3" json:"PPID,omitempty"`
 	WD              string    `protobuf:"bytes,13,opt,name=WD,proto3" json:"WD,omitempty"`
 	Hostname        string    `protobuf:"bytes,14,opt,name=Hostname,proto3" json:"Hostname,omitempty"`
 	UserCacheDir    string    `protobuf:"bytes,15,opt,name=UserCacheDir,proto3" json:"UserCacheDir,omitempty"`
@@ -1029,23 +1558,331 @@ type ProcessStats struct {
 	UserHomeDir     string    `protobuf:"bytes,17,opt,name=UserHomeDir,proto3" json:"UserHomeDir,omitempty"`
 }
 
-func (x *ProcessStats) Reset() {
-	*x = ProcessStats{}
+func (x *ProcessStats) Reset() {
+	*x = ProcessStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessStats) ProtoMessage() {}
+
+func (x *ProcessStats) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessStats.ProtoReflect.Descriptor instead.
+func (*ProcessStats) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ProcessStats) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetExecutable() string {
+	if x != nil {
+		return x.Executable
+	}
+	return ""
+}
+
+func (x *ProcessStats) GetExecutableLStat() *FileInfo {
+	if x != nil {
+		return x.ExecutableLStat
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetExecutableStat() *FileInfo {
+	if x != nil {
+		return x.ExecutableStat
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetUID() *IDName {
+	if x != nil {
+		return x.UID
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetGID() *IDName {
+	if x != nil {
+		return x.GID
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetEUID() *IDName {
+	if x != nil {
+		return x.EUID
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetEGID() *IDName {
+	if x != nil {
+		return x.EGID
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetGroups() []*IDName {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ProcessStats) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ProcessStats) GetPID() int32 {
+	if x != nil {
+		return x.PID
+	}
+	return 0
+}
+
+func (x *ProcessStats) GetPPID() int32 {
+	if x != nil {
+		return x.PPID
+	}
+	return 0
+}
+
+func (x *ProcessStats) GetWD() string {
+	if x != nil {
+		return x.WD
+	}
+	return ""
+}
+
+func (x *ProcessStats) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *ProcessStats) GetUserCacheDir() string {
+	if x != nil {
+		return x.UserCacheDir
+	}
+	return ""
+}
+
+func (x *ProcessStats) GetUserConfigDir() string {
+	if x != nil {
+		return x.UserConfigDir
+	}
+	return ""
+}
+
+func (x *ProcessStats) GetUserHomeDir() string {
+	if x != nil {
+		return x.UserHomeDir
+	}
+	return ""
+}
+
+type InfoType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GOOS              string            `protobuf:"bytes,1,opt,name=GOOS,proto3" json:"GOOS,omitempty"`
+	GOARCH            string            `protobuf:"bytes,2,opt,name=GOARCH,proto3" json:"GOARCH,omitempty"`
+	GOMAXPROCS        int32             `protobuf:"varint,3,opt,name=GOMAXPROCS,proto3" json:"GOMAXPROCS,omitempty"`
+	NumCPU            int32             `protobuf:"varint,4,opt,name=NumCPU,proto3" json:"NumCPU,omitempty"`
+	NumCgoCall        int32             `protobuf:"varint,5,opt,name=NumCgoCall,proto3" json:"NumCgoCall,omitempty"`
+	NumGoroutine      int32             `protobuf:"varint,6,opt,name=NumGoroutine,proto3" json:"NumGoroutine,omitempty"`
+	Version           string            `protobuf:"bytes,7,opt,name=Version,proto3" json:"Version,omitempty"`
+	ProcessStats      *ProcessStats     `protobuf:"bytes,8,opt,name=ProcessStats,proto3" json:"ProcessStats,omitempty"`
+	MemStats          *MemStats         `protobuf:"bytes,9,opt,name=MemStats,proto3" json:"MemStats,omitempty"`
+	MemProfileRate    int32             `protobuf:"varint,10,opt,name=MemProfileRate,proto3" json:"MemProfileRate,omitempty"`
+	NumUserGoroutines int32             `protobuf:"varint,11,opt,name=NumUserGoroutines,proto3" json:"NumUserGoroutines,omitempty"`
+	ActiveRPCs        int64             `protobuf:"varint,12,opt,name=ActiveRPCs,proto3" json:"ActiveRPCs,omitempty"`
+	TotalRPCs         map[string]uint64 `protobuf:"bytes,13,rep,name=TotalRPCs,proto3" json:"TotalRPCs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *InfoType) Reset() {
+	*x = InfoType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InfoType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoType) ProtoMessage() {}
+
+func (x *InfoType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoType.ProtoReflect.Descriptor instead.
+func (*InfoType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *InfoType) GetGOOS() string {
+	if x != nil {
+		return x.GOOS
+	}
+	return ""
+}
+
+func (x *InfoType) GetGOARCH() string {
+	if x != nil {
+		return x.GOARCH
+	}
+	return ""
+}
+
+func (x *InfoType) GetGOMAXPROCS() int32 {
+	if x != nil {
+		return x.GOMAXPROCS
+	}
+	return 0
+}
+
+func (x *InfoType) GetNumCPU() int32 {
+	if x != nil {
+		return x.NumCPU
+	}
+	return 0
+}
+
+func (x *InfoType) GetNumCgoCall() int32 {
+	if x != nil {
+		return x.NumCgoCall
+	}
+	return 0
+}
+
+func (x *InfoType) GetNumGoroutine() int32 {
+	if x != nil {
+		return x.NumGoroutine
+	}
+	return 0
+}
+
+func (x *InfoType) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *InfoType) GetProcessStats() *ProcessStats {
+	if x != nil {
+		return x.ProcessStats
+	}
+	return nil
+}
+
+func (x *InfoType) GetMemStats() *MemStats {
+	if x != nil {
+		return x.MemStats
+	}
+	return nil
+}
+
+func (x *InfoType) GetMemProfileRate() int32 {
+	if x != nil {
+		return x.MemProfileRate
+	}
+	return 0
+}
+
+func (x *InfoType) GetNumUserGoroutines() int32 {
+	if x != nil {
+		return x.NumUserGoroutines
+	}
+	return 0
+}
+
+func (x *InfoType) GetActiveRPCs() int64 {
+	if x != nil {
+		return x.ActiveRPCs
+	}
+	return 0
+}
+
+func (x *InfoType) GetTotalRPCs() map[string]uint64 {
+	if x != nil {
+		return x.TotalRPCs
+	}
+	return nil
+}
+
+type RuntimeMetric struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string            `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Kind    RuntimeMetricKind `protobuf:"varint,2,opt,name=Kind,proto3,enum=proto.RuntimeMetricKind" json:"Kind,omitempty"`
+	Uint64  uint64            `protobuf:"varint,3,opt,name=Uint64,proto3" json:"Uint64,omitempty"`
+	Float64 float64           `protobuf:"fixed64,4,opt,name=Float64,proto3" json:"Float64,omitempty"`
+	// Counts and Buckets are set for histograms, see runtime/metrics.Float64Histogram
+	Counts  []uint64  `protobuf:"varint,5,rep,packed,name=Counts,proto3" json:"Counts,omitempty"`
+	Buckets []float64 `protobuf:"fixed64,6,rep,packed,name=Buckets,proto3" json:"Buckets,omitempty"`
+}
+
+func (x *RuntimeMetric) Reset() {
+	*x = RuntimeMetric{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[12]
+		mi := &file_profile_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ProcessStats) String() string {
+func (x *RuntimeMetric) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessStats) ProtoMessage() {}
+func (*RuntimeMetric) ProtoMessage() {}
 
-func (x *ProcessStats) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[12]
+func (x *RuntimeMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,164 +1893,181 @@ func (x *ProcessStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessStats.ProtoReflect.Descriptor instead.
-func (*ProcessStats) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use RuntimeMetric.ProtoReflect.Descriptor instead.
+func (*RuntimeMetric) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ProcessStats) GetEnviron() []string {
+func (x *RuntimeMetric) GetName() string {
 	if x != nil {
-		return x.Environ
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *ProcessStats) GetExecutable() string {
+func (x *RuntimeMetric) GetKind() RuntimeMetricKind {
 	if x != nil {
-		return x.Executable
+		return x.Kind
 	}
-	return ""
+	return RuntimeMetricKind_metricKindBad
 }
 
-func (x *ProcessStats) GetExecutableLStat() *FileInfo {
+func (x *RuntimeMetric) GetUint64() uint64 {
 	if x != nil {
-		return x.ExecutableLStat
+		return x.Uint64
 	}
-	return nil
+	return 0
 }
 
-func (x *ProcessStats) GetExecutableStat() *FileInfo {
+func (x *RuntimeMetric) GetFloat64() float64 {
 	if x != nil {
-		return x.ExecutableStat
+		return x.Float64
 	}
-	return nil
+	return 0
 }
 
-func (x *ProcessStats) GetUID() *IDName {
+func (x *RuntimeMetric) GetCounts() []uint64 {
 	if x != nil {
-		return x.UID
+		return x.Counts
 	}
 	return nil
 }
 
-func (x *ProcessStats) GetGID() *IDName {
+func (x *RuntimeMetric) GetBuckets() []float64 {
 	if x != nil {
-		return x.GID
+		return x.Buckets
 	}
 	return nil
 }
 
-func (x *ProcessStats) GetEUID() *IDName {
-	if x != nil {
-		return x.EUID
-	}
-	return nil
+type RuntimeMetricsType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metrics []*RuntimeMetric `protobuf:"bytes,1,rep,name=Metrics,proto3" json:"Metrics,omitempty"`
 }
 
-func (x *ProcessStats) GetEGID() *IDName {
-	if x != nil {
-		return x.EGID
+func (x *RuntimeMetricsType) Reset() {
+	*x = RuntimeMetricsType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ProcessStats) GetGroups() []*IDName {
-	if x != nil {
-		return x.Groups
-	}
-	return nil
+func (x *RuntimeMetricsType) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ProcessStats) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
+func (*RuntimeMetricsType) ProtoMessage() {}
+
+func (x *RuntimeMetricsType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ProcessStats) GetPID() int32 {
-	if x != nil {
-		return x.PID
-	}
-	return 0
+// Deprecated: Use RuntimeMetricsType.ProtoReflect.Descriptor instead.
+func (*RuntimeMetricsType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ProcessStats) GetPPID() int32 {
+func (x *RuntimeMetricsType) GetMetrics() []*RuntimeMetric {
 	if x != nil {
-		return x.PPID
+		return x.Metrics
 	}
-	return 0
+	return nil
 }
 
-func (x *ProcessStats) GetWD() string {
-	if x != nil {
-		return x.WD
-	}
-	return ""
+type VersionType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  string   `protobuf:"bytes,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	Features []string `protobuf:"bytes,2,rep,name=Features,proto3" json:"Features,omitempty"`
 }
 
-func (x *ProcessStats) GetHostname() string {
-	if x != nil {
-		return x.Hostname
+func (x *VersionType) Reset() {
+	*x = VersionType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *ProcessStats) GetUserCacheDir() string {
-	if x != nil {
-		return x.UserCacheDir
+func (x *VersionType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionType) ProtoMessage() {}
+
+func (x *VersionType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ProcessStats) GetUserConfigDir() string {
+// Deprecated: Use VersionType.ProtoReflect.Descriptor instead.
+func (*VersionType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VersionType) GetVersion() string {
 	if x != nil {
-		return x.UserConfigDir
+		return x.Version
 	}
 	return ""
 }
 
-func (x *ProcessStats) GetUserHomeDir() string {
+func (x *VersionType) GetFeatures() []string {
 	if x != nil {
-		return x.UserHomeDir
+		return x.Features
 	}
-	return ""
+	return nil
 }
 
-type InfoType struct {
+type BinaryHashType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GOOS           string        `protobuf:"bytes,1,opt,name=GOOS,proto3" json:"GOOS,omitempty"`
-	GOARCH         string        `protobuf:"bytes,2,opt,name=GOARCH,proto3" json:"GOARCH,omitempty"`
-	GOMAXPROCS     int32         `protobuf:"varint,3,opt,name=GOMAXPROCS,proto3" json:"GOMAXPROCS,omitempty"`
-	NumCPU         int32         `protobuf:"varint,4,opt,name=NumCPU,proto3" json:"NumCPU,omitempty"`
-	NumCgoCall     int32         `protobuf:"varint,5,opt,name=NumCgoCall,proto3" json:"NumCgoCall,omitempty"`
-	NumGoroutine   int32         `protobuf:"varint,6,opt,name=NumGoroutine,proto3" json:"NumGoroutine,omitempty"`
-	Version        string        `protobuf:"bytes,7,opt,name=Version,proto3" json:"Version,omitempty"`
-	ProcessStats   *ProcessStats `protobuf:"bytes,8,opt,name=ProcessStats,proto3" json:"ProcessStats,omitempty"`
-	MemStats       *MemStats     `protobuf:"bytes,9,opt,name=MemStats,proto3" json:"MemStats,omitempty"`
-	MemProfileRate int32         `protobuf:"varint,10,opt,name=MemProfileRate,proto3" json:"MemProfileRate,omitempty"`
+	SHA256 string `protobuf:"bytes,1,opt,name=SHA256,proto3" json:"SHA256,omitempty"`
+	Size   int64  `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
 }
 
-func (x *InfoType) Reset() {
-	*x = InfoType{}
+func (x *BinaryHashType) Reset() {
+	*x = BinaryHashType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_profile_proto_msgTypes[13]
+		mi := &file_profile_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InfoType) String() string {
+func (x *BinaryHashType) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InfoType) ProtoMessage() {}
+func (*BinaryHashType) ProtoMessage() {}
 
-func (x *InfoType) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_proto_msgTypes[13]
+func (x *BinaryHashType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1224,79 +2078,70 @@ func (x *InfoType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InfoType.ProtoReflect.Descriptor instead.
-func (*InfoType) Descriptor() ([]byte, []int) {
-	return file_profile_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *InfoType) GetGOOS() string {
-	if x != nil {
-		return x.GOOS
-	}
-	return ""
+// Deprecated: Use BinaryHashType.ProtoReflect.Descriptor instead.
+func (*BinaryHashType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *InfoType) GetGOARCH() string {
+func (x *BinaryHashType) GetSHA256() string {
 	if x != nil {
-		return x.GOARCH
+		return x.SHA256
 	}
 	return ""
 }
 
-func (x *InfoType) GetGOMAXPROCS() int32 {
+func (x *BinaryHashType) GetSize() int64 {
 	if x != nil {
-		return x.GOMAXPROCS
+		return x.Size
 	}
 	return 0
 }
 
-func (x *InfoType) GetNumCPU() int32 {
-	if x != nil {
-		return x.NumCPU
-	}
-	return 0
-}
+type WatchInfoInputType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *InfoType) GetNumCgoCall() int32 {
-	if x != nil {
-		return x.NumCgoCall
-	}
-	return 0
+	Interval *duration.Duration `protobuf:"bytes,1,opt,name=Interval,proto3" json:"Interval,omitempty"`
 }
 
-func (x *InfoType) GetNumGoroutine() int32 {
-	if x != nil {
-		return x.NumGoroutine
+func (x *WatchInfoInputType) Reset() {
+	*x = WatchInfoInputType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_profile_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *InfoType) GetVersion() string {
-	if x != nil {
-		return x.Version
-	}
-	return ""
+func (x *WatchInfoInputType) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *InfoType) GetProcessStats() *ProcessStats {
-	if x != nil {
-		return x.ProcessStats
+func (*WatchInfoInputType) ProtoMessage() {}
+
+func (x *WatchInfoInputType) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *InfoType) GetMemStats() *MemStats {
-	if x != nil {
-		return x.MemStats
-	}
-	return nil
+// Deprecated: Use WatchInfoInputType.ProtoReflect.Descriptor instead.
+func (*WatchInfoInputType) Descriptor() ([]byte, []int) {
+	return file_profile_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *InfoType) GetMemProfileRate() int32 {
+func (x *WatchInfoInputType) GetInterval() *duration.Duration {
 	if x != nil {
-		return x.MemProfileRate
+		return x.Interval
 	}
-	return 0
+	return nil
 }
 
 var File_profile_proto protoreflect.FileDescriptor
@@ -1309,223 +2154,398 @@ var file_profile_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
 	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x25, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e,
-	0x6b, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x26, 0x0a, 0x0a, 0x53,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x1f, 0x0a, 0x07, 0x49, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x22, 0x43, 0x0a, 0x11, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x50, 0x72, 0x6f,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
-	0x52, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x49, 0x0a, 0x14, 0x4e, 0x6f, 0x6e,
-	0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x31, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x47, 0x6f, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x47, 0x6f,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x26,
+	0x0a, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61,
+	0x52, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x1f,
+	0x0a, 0x07, 0x49, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x43, 0x0a, 0x11, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f,
 	0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x50, 0x72, 0x6f,
-	0x66, 0x69, 0x6c, 0x65, 0x22, 0x5d, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69,
-	0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x56,
-	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x61, 0x72,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x52, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x52,
-	0x61, 0x74, 0x65, 0x22, 0x4b, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66,
-	0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x08,
-	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x61,
-	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
-	0x22, 0x50, 0x0a, 0x16, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x50, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x19, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
-	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x39, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f,
-	0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b,
-	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x44,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0xdd, 0x06, 0x0a, 0x08, 0x4d, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6c,
-	0x6c, 0x6f, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c,
-	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x03, 0x53, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
-	0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x73, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x07, 0x4d, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x46,
-	0x72, 0x65, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x46, 0x72, 0x65, 0x65,
-	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x70, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x48, 0x65, 0x61, 0x70, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x12,
-	0x18, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x70, 0x53, 0x79, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x07, 0x48, 0x65, 0x61, 0x70, 0x53, 0x79, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65, 0x61,
-	0x70, 0x49, 0x64, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x48, 0x65, 0x61,
-	0x70, 0x49, 0x64, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x70, 0x49, 0x6e, 0x75,
-	0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x48, 0x65, 0x61, 0x70, 0x49, 0x6e,
-	0x75, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61,
-	0x73, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x48, 0x65, 0x61, 0x70, 0x52,
-	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x70, 0x4f,
-	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x48, 0x65,
-	0x61, 0x70, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x61,
-	0x63, 0x6b, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x53,
-	0x74, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x74, 0x61,
-	0x63, 0x6b, 0x53, 0x79, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x53, 0x74, 0x61,
-	0x63, 0x6b, 0x53, 0x79, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x53, 0x70, 0x61, 0x6e, 0x49, 0x6e,
-	0x75, 0x73, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x4d, 0x53, 0x70, 0x61, 0x6e,
-	0x49, 0x6e, 0x75, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x53, 0x70, 0x61, 0x6e, 0x53, 0x79,
-	0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4d, 0x53, 0x70, 0x61, 0x6e, 0x53, 0x79,
-	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6e, 0x75, 0x73, 0x65,
-	0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x4d, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6e,
-	0x75, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x79, 0x73,
-	0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x4d, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x79,
-	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x75, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x53, 0x79, 0x73,
-	0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x42, 0x75, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
-	0x53, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x47, 0x43, 0x53, 0x79, 0x73, 0x18, 0x14, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x47, 0x43, 0x53, 0x79, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4f, 0x74, 0x68,
-	0x65, 0x72, 0x53, 0x79, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4f, 0x74, 0x68,
-	0x65, 0x72, 0x53, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x65, 0x78, 0x74, 0x47, 0x43, 0x18,
-	0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4e, 0x65, 0x78, 0x74, 0x47, 0x43, 0x12, 0x32, 0x0a,
-	0x06, 0x4c, 0x61, 0x73, 0x74, 0x47, 0x43, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x4c, 0x61, 0x73, 0x74, 0x47,
-	0x43, 0x12, 0x3d, 0x0a, 0x0c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e,
-	0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x0c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x73,
-	0x12, 0x38, 0x0a, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x18, 0x19, 0x20,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0x49, 0x0a, 0x14, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x31, 0x0a, 0x07,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22,
+	0x5d, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x52, 0x61, 0x74, 0x65, 0x22, 0x49,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52,
+	0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x4b, 0x0a, 0x15, 0x52, 0x65, 0x73,
+	0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x08, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x59, 0x0a, 0x0d, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x3d, 0x0a, 0x0d, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x22, 0xd4, 0x02, 0x0a, 0x16, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x65, 0x62, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x43, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x47, 0x43, 0x42, 0x65, 0x66, 0x6f, 0x72,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x47, 0x43, 0x42, 0x65, 0x66, 0x6f, 0x72,
+	0x65, 0x12, 0x53, 0x0a, 0x0c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc2, 0x02, 0x0a, 0x19, 0x4e, 0x6f, 0x6e, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x35, 0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x43, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x56, 0x0a, 0x0c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xaa, 0x01, 0x0a,
+	0x1a, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b,
+	0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1a, 0x0a,
+	0x08, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x22, 0x50, 0x0a, 0x11, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3b,
+	0x0a, 0x0b, 0x43, 0x50, 0x55, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x43, 0x50, 0x55, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5c, 0x0a, 0x0c, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xdd, 0x06, 0x0a, 0x08, 0x4d, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x12, 0x1e, 0x0a, 0x0a,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x12, 0x10, 0x0a, 0x03,
+	0x53, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x53, 0x79, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x61, 0x6c, 0x6c,
+	0x6f, 0x63, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x4d, 0x61, 0x6c, 0x6c, 0x6f,
+	0x63, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x46, 0x72, 0x65, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x46, 0x72, 0x65, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x70,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x48, 0x65, 0x61,
+	0x70, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x70, 0x53, 0x79,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x48, 0x65, 0x61, 0x70, 0x53, 0x79, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x70, 0x49, 0x64, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x48, 0x65, 0x61, 0x70, 0x49, 0x64, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x48, 0x65, 0x61, 0x70, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x48, 0x65, 0x61, 0x70, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x48, 0x65,
+	0x61, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x48, 0x65, 0x61, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x12, 0x20,
+	0x0a, 0x0b, 0x48, 0x65, 0x61, 0x70, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x48, 0x65, 0x61, 0x70, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x75, 0x73, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x53, 0x79, 0x73, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x53, 0x79, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x4d, 0x53, 0x70, 0x61, 0x6e, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x4d, 0x53, 0x70, 0x61, 0x6e, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x4d, 0x53, 0x70, 0x61, 0x6e, 0x53, 0x79, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x4d, 0x53, 0x70, 0x61, 0x6e, 0x53, 0x79, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x4d,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x53, 0x79, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x4d,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x79, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x75, 0x63, 0x6b,
+	0x48, 0x61, 0x73, 0x68, 0x53, 0x79, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x42,
+	0x75, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x53, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x47, 0x43,
+	0x53, 0x79, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x47, 0x43, 0x53, 0x79, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x53, 0x79, 0x73, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x53, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x4e, 0x65, 0x78, 0x74, 0x47, 0x43, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4e, 0x65,
+	0x78, 0x74, 0x47, 0x43, 0x12, 0x32, 0x0a, 0x06, 0x4c, 0x61, 0x73, 0x74, 0x47, 0x43, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x06, 0x4c, 0x61, 0x73, 0x74, 0x47, 0x43, 0x12, 0x3d, 0x0a, 0x0c, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x50, 0x61, 0x75, 0x73, 0x65,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x75, 0x6d, 0x47, 0x43, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x4e, 0x75, 0x6d, 0x47, 0x43, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x75, 0x6d, 0x46, 0x6f,
+	0x72, 0x63, 0x65, 0x64, 0x47, 0x43, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x4e, 0x75,
+	0x6d, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x47, 0x43, 0x22, 0x7e, 0x0a, 0x08, 0x46, 0x69, 0x6c,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x36, 0x0a, 0x08, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x09, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x75,
-	0x6d, 0x47, 0x43, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x4e, 0x75, 0x6d, 0x47, 0x43,
-	0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x75, 0x6d, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x47, 0x43, 0x18,
-	0x1b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x4e, 0x75, 0x6d, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x64,
-	0x47, 0x43, 0x22, 0x7e, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12,
-	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x4d, 0x6f,
-	0x64, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x69,
-	0x6d, 0x65, 0x22, 0x2c, 0x0a, 0x06, 0x49, 0x44, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0xc5, 0x04, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x07, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0f, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4c, 0x53, 0x74, 0x61, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x4c, 0x53, 0x74, 0x61, 0x74, 0x12, 0x37, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x12,
-	0x1f, 0x0a, 0x03, 0x55, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x44, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x03, 0x55, 0x49, 0x44,
-	0x12, 0x1f, 0x0a, 0x03, 0x47, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x44, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x03, 0x47, 0x49,
-	0x44, 0x12, 0x21, 0x0a, 0x04, 0x45, 0x55, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x44, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x04,
-	0x45, 0x55, 0x49, 0x44, 0x12, 0x21, 0x0a, 0x04, 0x45, 0x47, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x44, 0x4e, 0x61, 0x6d,
-	0x65, 0x52, 0x04, 0x45, 0x47, 0x49, 0x44, 0x12, 0x25, 0x0a, 0x06, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x49, 0x44, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1a,
-	0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x49,
-	0x44, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x50, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04,
-	0x50, 0x50, 0x49, 0x44, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x50, 0x49, 0x44,
-	0x12, 0x0e, 0x0a, 0x02, 0x57, 0x44, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x57, 0x44,
-	0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c,
-	0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x44, 0x69, 0x72, 0x18, 0x0f, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x44, 0x69, 0x72,
-	0x12, 0x24, 0x0a, 0x0d, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69,
-	0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x44, 0x69, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x48, 0x6f,
-	0x6d, 0x65, 0x44, 0x69, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x55, 0x73, 0x65,
-	0x72, 0x48, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x22, 0xda, 0x02, 0x0a, 0x08, 0x49, 0x6e, 0x66,
-	0x6f, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x47, 0x4f, 0x4f, 0x53, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x47, 0x4f, 0x4f, 0x53, 0x12, 0x16, 0x0a, 0x06, 0x47, 0x4f, 0x41,
-	0x52, 0x43, 0x48, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x47, 0x4f, 0x41, 0x52, 0x43,
-	0x48, 0x12, 0x1e, 0x0a, 0x0a, 0x47, 0x4f, 0x4d, 0x41, 0x58, 0x50, 0x52, 0x4f, 0x43, 0x53, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x47, 0x4f, 0x4d, 0x41, 0x58, 0x50, 0x52, 0x4f, 0x43,
-	0x53, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x75, 0x6d, 0x43, 0x50, 0x55, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x4e, 0x75, 0x6d, 0x43, 0x50, 0x55, 0x12, 0x1e, 0x0a, 0x0a, 0x4e, 0x75, 0x6d,
-	0x43, 0x67, 0x6f, 0x43, 0x61, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x4e,
-	0x75, 0x6d, 0x43, 0x67, 0x6f, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x22, 0x0a, 0x0c, 0x4e, 0x75, 0x6d,
-	0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0c, 0x4e, 0x75, 0x6d, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x52, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x12, 0x2b, 0x0a, 0x08, 0x4d, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x65, 0x6d, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x52, 0x08, 0x4d, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a,
-	0x0e, 0x4d, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x4d, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x52, 0x61, 0x74, 0x65, 0x2a, 0x69, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
-	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x65, 0x6d, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
-	0x43, 0x50, 0x55, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x10, 0x01,
-	0x12, 0x18, 0x0a, 0x14, 0x4d, 0x75, 0x74, 0x65, 0x78, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
-	0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x10, 0x03,
-	0x2a, 0x87, 0x01, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69,
-	0x6c, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x48, 0x65, 0x61, 0x70, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x66, 0x69,
-	0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x75, 0x74, 0x65, 0x78, 0x10, 0x01, 0x12, 0x14, 0x0a,
-	0x10, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x10, 0x03,
-	0x12, 0x18, 0x0a, 0x14, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x47,
-	0x6f, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x10, 0x04, 0x2a, 0x3c, 0x0a, 0x10, 0x4e, 0x6f,
-	0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12,
-	0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x43, 0x50, 0x55,
-	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x10, 0x01, 0x32, 0xf5, 0x03, 0x0a, 0x0e, 0x50, 0x72, 0x6f,
-	0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x50,
-	0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x32,
-	0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x44, 0x75, 0x6d, 0x70,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x31, 0x0a, 0x03,
-	0x53, 0x65, 0x74, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a,
-	0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x34, 0x0a, 0x02, 0x47, 0x43, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e,
+	0x08, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x2c, 0x0a, 0x06, 0x49, 0x44, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xc5, 0x04, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x76, 0x69,
+	0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x4c, 0x53, 0x74, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0f, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4c, 0x53, 0x74, 0x61, 0x74, 0x12, 0x37, 0x0a,
+	0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x03, 0x55, 0x49, 0x44, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x44, 0x4e, 0x61,
+	0x6d, 0x65, 0x52, 0x03, 0x55, 0x49, 0x44, 0x12, 0x1f, 0x0a, 0x03, 0x47, 0x49, 0x44, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x44, 0x4e,
+	0x61, 0x6d, 0x65, 0x52, 0x03, 0x47, 0x49, 0x44, 0x12, 0x21, 0x0a, 0x04, 0x45, 0x55, 0x49, 0x44,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49,
+	0x44, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x45, 0x55, 0x49, 0x44, 0x12, 0x21, 0x0a, 0x04, 0x45,
+	0x47, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x49, 0x44, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x45, 0x47, 0x49, 0x44, 0x12, 0x25,
+	0x0a, 0x06, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x44, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x49, 0x44, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x50, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x50, 0x49, 0x44, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x50, 0x50, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x57, 0x44, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x57, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x44, 0x69, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x44, 0x69, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x55, 0x73, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x69, 0x72, 0x12, 0x20, 0x0a,
+	0x0b, 0x55, 0x73, 0x65, 0x72, 0x48, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x48, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x22,
+	0xa4, 0x04, 0x0a, 0x08, 0x49, 0x6e, 0x66, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x47, 0x4f, 0x4f, 0x53, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x47, 0x4f, 0x4f, 0x53,
+	0x12, 0x16, 0x0a, 0x06, 0x47, 0x4f, 0x41, 0x52, 0x43, 0x48, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x47, 0x4f, 0x41, 0x52, 0x43, 0x48, 0x12, 0x1e, 0x0a, 0x0a, 0x47, 0x4f, 0x4d, 0x41,
+	0x58, 0x50, 0x52, 0x4f, 0x43, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x47, 0x4f,
+	0x4d, 0x41, 0x58, 0x50, 0x52, 0x4f, 0x43, 0x53, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x75, 0x6d, 0x43,
+	0x50, 0x55, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4e, 0x75, 0x6d, 0x43, 0x50, 0x55,
+	0x12, 0x1e, 0x0a, 0x0a, 0x4e, 0x75, 0x6d, 0x43, 0x67, 0x6f, 0x43, 0x61, 0x6c, 0x6c, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x4e, 0x75, 0x6d, 0x43, 0x67, 0x6f, 0x43, 0x61, 0x6c, 0x6c,
+	0x12, 0x22, 0x0a, 0x0c, 0x4e, 0x75, 0x6d, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x4e, 0x75, 0x6d, 0x47, 0x6f, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37,
+	0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x4d, 0x65, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4d, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x4d, 0x65, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x4d, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x4d, 0x65,
+	0x6d, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x11,
+	0x4e, 0x75, 0x6d, 0x55, 0x73, 0x65, 0x72, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x4e, 0x75, 0x6d, 0x55, 0x73, 0x65, 0x72,
+	0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x52, 0x50, 0x43, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x50, 0x43, 0x73, 0x12, 0x3c, 0x0a, 0x09, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x52, 0x50, 0x43, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x52, 0x50, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x52, 0x50, 0x43, 0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x52, 0x50, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb5, 0x01, 0x0a, 0x0d, 0x52, 0x75, 0x6e, 0x74, 0x69,
+	0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x04,
+	0x4b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x69,
+	0x6e, 0x74, 0x36, 0x34, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x55, 0x69, 0x6e, 0x74,
+	0x36, 0x34, 0x12, 0x18, 0x0a, 0x07, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x12, 0x16, 0x0a, 0x06,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x01, 0x52, 0x07, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x44,
+	0x0a, 0x12, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x75,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x07, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x22, 0x43, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x3c, 0x0a, 0x0e, 0x42, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x48, 0x61, 0x73, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53,
+	0x48, 0x41, 0x32, 0x35, 0x36, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x48, 0x41,
+	0x32, 0x35, 0x36, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x4b, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x6e, 0x66, 0x6f, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a,
+	0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x2a, 0x78, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x65, 0x6d, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43,
+	0x50, 0x55, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x4d, 0x75, 0x74, 0x65, 0x78, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x46,
+	0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x10, 0x03, 0x12,
+	0x0d, 0x0a, 0x09, 0x47, 0x43, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x10, 0x04, 0x2a, 0x9e,
+	0x01, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x13, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x48,
+	0x65, 0x61, 0x70, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x4d, 0x75, 0x74, 0x65, 0x78, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x10,
+	0x02, 0x12, 0x1b, 0x0a, 0x17, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x10, 0x03, 0x12, 0x18,
+	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x47, 0x6f, 0x52,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x73, 0x10, 0x05, 0x2a,
+	0x3c, 0x0a, 0x10, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x43, 0x50, 0x55, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x10, 0x01, 0x2a, 0x73, 0x0a,
+	0x11, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4b, 0x69,
+	0x6e, 0x64, 0x12, 0x11, 0x0a, 0x0d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4b, 0x69, 0x6e, 0x64,
+	0x42, 0x61, 0x64, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4b,
+	0x69, 0x6e, 0x64, 0x55, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x6d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x4b, 0x69, 0x6e, 0x64, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34,
+	0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4b, 0x69, 0x6e, 0x64,
+	0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x10, 0x03, 0x32, 0xc3, 0x09, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c,
-	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69,
-	0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x10, 0x4e, 0x6f, 0x6e,
-	0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a,
-	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e,
-	0x6b, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f,
-	0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f,
-	0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x32, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e,
+	0x66, 0x6f, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x0f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x30, 0x01, 0x12, 0x46,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79,
+	0x44, 0x75, 0x6d, 0x70, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01,
+	0x12, 0x3b, 0x0a, 0x0a, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x48, 0x61, 0x73, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x31, 0x0a,
+	0x03, 0x53, 0x65, 0x74, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x39, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x12,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x31, 0x0a, 0x03, 0x47,
+	0x65, 0x74, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x0e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x35,
+	0x0a, 0x05, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x65, 0x74, 0x41, 0x6c,
+	0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x2d, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x50, 0x72, 0x6f, 0x63, 0x73, 0x12, 0x0e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x0e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x34,
+	0x0a, 0x02, 0x47, 0x43, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0c, 0x46, 0x72, 0x65, 0x65, 0x4f, 0x53, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x10, 0x4e, 0x6f, 0x6e, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x30, 0x01, 0x12, 0x4b, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x4d, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x30, 0x01, 0x12, 0x38,
+	0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1540,72 +2560,127 @@ func file_profile_proto_rawDescGZIP() []byte {
 	return file_profile_proto_rawDescData
 }
 
-var file_profile_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_profile_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_profile_proto_goTypes = []interface{}{
-	(ProfileVariable)(0),              // 0: proto.ProfileVariable
-	(LookupProfile)(0),                // 1: proto.LookupProfile
-	(NonLookupProfile)(0),             // 2: proto.NonLookupProfile
-	(*FileChunk)(nil),                 // 3: proto.FileChunk
-	(*StringType)(nil),                // 4: proto.StringType
-	(*IntType)(nil),                   // 5: proto.IntType
-	(*LookupProfileType)(nil),         // 6: proto.LookupProfileType
-	(*NonLookupProfileType)(nil),      // 7: proto.NonLookupProfileType
-	(*SetProfileInputType)(nil),       // 8: proto.SetProfileInputType
-	(*ResetProfileInputType)(nil),     // 9: proto.ResetProfileInputType
-	(*LookupProfileInputType)(nil),    // 10: proto.LookupProfileInputType
-	(*NonLookupProfileInputType)(nil), // 11: proto.NonLookupProfileInputType
-	(*MemStats)(nil),                  // 12: proto.MemStats
-	(*FileInfo)(nil),                  // 13: proto.FileInfo
-	(*IDName)(nil),                    // 14: proto.IDName
-	(*ProcessStats)(nil),              // 15: proto.ProcessStats
-	(*InfoType)(nil),                  // 16: proto.InfoType
-	(*duration.Duration)(nil),         // 17: google.protobuf.Duration
-	(*timestamp.Timestamp)(nil),       // 18: google.protobuf.Timestamp
-	(*empty.Empty)(nil),               // 19: google.protobuf.Empty
+	(ProfileVariable)(0),               // 0: proto.ProfileVariable
+	(LookupProfile)(0),                 // 1: proto.LookupProfile
+	(NonLookupProfile)(0),              // 2: proto.NonLookupProfile
+	(RuntimeMetricKind)(0),             // 3: proto.RuntimeMetricKind
+	(*ProfileMeta)(nil),                // 4: proto.ProfileMeta
+	(*FileChunk)(nil),                  // 5: proto.FileChunk
+	(*StringType)(nil),                 // 6: proto.StringType
+	(*IntType)(nil),                    // 7: proto.IntType
+	(*LookupProfileType)(nil),          // 8: proto.LookupProfileType
+	(*NonLookupProfileType)(nil),       // 9: proto.NonLookupProfileType
+	(*SetProfileInputType)(nil),        // 10: proto.SetProfileInputType
+	(*GetProfileInputType)(nil),        // 11: proto.GetProfileInputType
+	(*ResetProfileInputType)(nil),      // 12: proto.ResetProfileInputType
+	(*VariableValue)(nil),              // 13: proto.VariableValue
+	(*VariablesType)(nil),              // 14: proto.VariablesType
+	(*LookupProfileInputType)(nil),     // 15: proto.LookupProfileInputType
+	(*NonLookupProfileInputType)(nil),  // 16: proto.NonLookupProfileInputType
+	(*ContinuousProfileInputType)(nil), // 17: proto.ContinuousProfileInputType
+	(*SnapshotInputType)(nil),          // 18: proto.SnapshotInputType
+	(*ProfileFrame)(nil),               // 19: proto.ProfileFrame
+	(*MemStats)(nil),                   // 20: proto.MemStats
+	(*FileInfo)(nil),                   // 21: proto.FileInfo
+	(*IDName)(nil),                     // 22: proto.IDName
+	(*ProcessStats)(nil),               // 23: proto.ProcessStats
+	(*InfoType)(nil),                   // 24: proto.InfoType
+	(*RuntimeMetric)(nil),              // 25: proto.RuntimeMetric
+	(*RuntimeMetricsType)(nil),         // 26: proto.RuntimeMetricsType
+	(*VersionType)(nil),                // 27: proto.VersionType
+	(*BinaryHashType)(nil),             // 28: proto.BinaryHashType
+	(*WatchInfoInputType)(nil),         // 29: proto.WatchInfoInputType
+	nil,                                // 30: proto.LookupProfileInputType.LabelFiltersEntry
+	nil,                                // 31: proto.NonLookupProfileInputType.LabelFiltersEntry
+	nil,                                // 32: proto.InfoType.TotalRPCsEntry
+	(*timestamp.Timestamp)(nil),        // 33: google.protobuf.Timestamp
+	(*duration.Duration)(nil),          // 34: google.protobuf.Duration
+	(*empty.Empty)(nil),                // 35: google.protobuf.Empty
 }
 var file_profile_proto_depIdxs = []int32{
-	1,  // 0: proto.LookupProfileType.Profile:type_name -> proto.LookupProfile
-	2,  // 1: proto.NonLookupProfileType.Profile:type_name -> proto.NonLookupProfile
-	0,  // 2: proto.SetProfileInputType.Variable:type_name -> proto.ProfileVariable
-	0,  // 3: proto.ResetProfileInputType.Variable:type_name -> proto.ProfileVariable
-	1,  // 4: proto.LookupProfileInputType.ProfileType:type_name -> proto.LookupProfile
-	2,  // 5: proto.NonLookupProfileInputType.ProfileType:type_name -> proto.NonLookupProfile
-	17, // 6: proto.NonLookupProfileInputType.Duration:type_name -> google.protobuf.Duration
-	18, // 7: proto.MemStats.LastGC:type_name -> google.protobuf.Timestamp
-	17, // 8: proto.MemStats.PauseTotalNs:type_name -> google.protobuf.Duration
-	18, // 9: proto.MemStats.LastPause:type_name -> google.protobuf.Timestamp
-	18, // 10: proto.FileInfo.ModeTime:type_name -> google.protobuf.Timestamp
-	13, // 11: proto.ProcessStats.ExecutableLStat:type_name -> proto.FileInfo
-	13, // 12: proto.ProcessStats.ExecutableStat:type_name -> proto.FileInfo
-	14, // 13: proto.ProcessStats.UID:type_name -> proto.IDName
-	14, // 14: proto.ProcessStats.GID:type_name -> proto.IDName
-	14, // 15: proto.ProcessStats.EUID:type_name -> proto.IDName
-	14, // 16: proto.ProcessStats.EGID:type_name -> proto.IDName
-	14, // 17: proto.ProcessStats.Groups:type_name -> proto.IDName
-	15, // 18: proto.InfoType.ProcessStats:type_name -> proto.ProcessStats
-	12, // 19: proto.InfoType.MemStats:type_name -> proto.MemStats
-	19, // 20: proto.ProfileService.Ping:input_type -> google.protobuf.Empty
-	19, // 21: proto.ProfileService.GetInfo:input_type -> google.protobuf.Empty
-	19, // 22: proto.ProfileService.BinaryDump:input_type -> google.protobuf.Empty
-	8,  // 23: proto.ProfileService.Set:input_type -> proto.SetProfileInputType
-	19, // 24: proto.ProfileService.GC:input_type -> google.protobuf.Empty
-	10, // 25: proto.ProfileService.LookupProfile:input_type -> proto.LookupProfileInputType
-	11, // 26: proto.ProfileService.NonLookupProfile:input_type -> proto.NonLookupProfileInputType
-	7,  // 27: proto.ProfileService.StopNonLookupProfile:input_type -> proto.NonLookupProfileType
-	4,  // 28: proto.ProfileService.Ping:output_type -> proto.StringType
-	16, // 29: proto.ProfileService.GetInfo:output_type -> proto.InfoType
-	3,  // 30: proto.ProfileService.BinaryDump:output_type -> proto.FileChunk
-	5,  // 31: proto.ProfileService.Set:output_type -> proto.IntType
-	19, // 32: proto.ProfileService.GC:output_type -> google.protobuf.Empty
-	3,  // 33: proto.ProfileService.LookupProfile:output_type -> proto.FileChunk
-	3,  // 34: proto.ProfileService.NonLookupProfile:output_type -> proto.FileChunk
-	19, // 35: proto.ProfileService.StopNonLookupProfile:output_type -> google.protobuf.Empty
-	28, // [28:36] is the sub-list for method output_type
-	20, // [20:28] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	33, // 0: proto.ProfileMeta.Time:type_name -> google.protobuf.Timestamp
+	34, // 1: proto.ProfileMeta.Duration:type_name -> google.protobuf.Duration
+	4,  // 2: proto.FileChunk.Meta:type_name -> proto.ProfileMeta
+	1,  // 3: proto.LookupProfileType.Profile:type_name -> proto.LookupProfile
+	2,  // 4: proto.NonLookupProfileType.Profile:type_name -> proto.NonLookupProfile
+	0,  // 5: proto.SetProfileInputType.Variable:type_name -> proto.ProfileVariable
+	0,  // 6: proto.GetProfileInputType.Variable:type_name -> proto.ProfileVariable
+	0,  // 7: proto.ResetProfileInputType.Variable:type_name -> proto.ProfileVariable
+	0,  // 8: proto.VariableValue.Variable:type_name -> proto.ProfileVariable
+	13, // 9: proto.VariablesType.Values:type_name -> proto.VariableValue
+	1,  // 10: proto.LookupProfileInputType.ProfileType:type_name -> proto.LookupProfile
+	30, // 11: proto.LookupProfileInputType.LabelFilters:type_name -> proto.LookupProfileInputType.LabelFiltersEntry
+	2,  // 12: proto.NonLookupProfileInputType.ProfileType:type_name -> proto.NonLookupProfile
+	34, // 13: proto.NonLookupProfileInputType.Duration:type_name -> google.protobuf.Duration
+	31, // 14: proto.NonLookupProfileInputType.LabelFilters:type_name -> proto.NonLookupProfileInputType.LabelFiltersEntry
+	2,  // 15: proto.ContinuousProfileInputType.ProfileType:type_name -> proto.NonLookupProfile
+	34, // 16: proto.ContinuousProfileInputType.Interval:type_name -> google.protobuf.Duration
+	34, // 17: proto.SnapshotInputType.CPUDuration:type_name -> google.protobuf.Duration
+	33, // 18: proto.MemStats.LastGC:type_name -> google.protobuf.Timestamp
+	34, // 19: proto.MemStats.PauseTotalNs:type_name -> google.protobuf.Duration
+	33, // 20: proto.MemStats.LastPause:type_name -> google.protobuf.Timestamp
+	33, // 21: proto.FileInfo.ModeTime:type_name -> google.protobuf.Timestamp
+	21, // 22: proto.ProcessStats.ExecutableLStat:type_name -> proto.FileInfo
+	21, // 23: proto.ProcessStats.ExecutableStat:type_name -> proto.FileInfo
+	22, // 24: proto.ProcessStats.UID:type_name -> proto.IDName
+	22, // 25: proto.ProcessStats.GID:type_name -> proto.IDName
+	22, // 26: proto.ProcessStats.EUID:type_name -> proto.IDName
+	22, // 27: proto.ProcessStats.EGID:type_name -> proto.IDName
+	22, // 28: proto.ProcessStats.Groups:type_name -> proto.IDName
+	23, // 29: proto.InfoType.ProcessStats:type_name -> proto.ProcessStats
+	20, // 30: proto.InfoType.MemStats:type_name -> proto.MemStats
+	32, // 31: proto.InfoType.TotalRPCs:type_name -> proto.InfoType.TotalRPCsEntry
+	3,  // 32: proto.RuntimeMetric.Kind:type_name -> proto.RuntimeMetricKind
+	25, // 33: proto.RuntimeMetricsType.Metrics:type_name -> proto.RuntimeMetric
+	34, // 34: proto.WatchInfoInputType.Interval:type_name -> google.protobuf.Duration
+	35, // 35: proto.ProfileService.Ping:input_type -> google.protobuf.Empty
+	35, // 36: proto.ProfileService.Version:input_type -> google.protobuf.Empty
+	35, // 37: proto.ProfileService.GetInfo:input_type -> google.protobuf.Empty
+	29, // 38: proto.ProfileService.WatchInfo:input_type -> proto.WatchInfoInputType
+	35, // 39: proto.ProfileService.GetRuntimeMetrics:input_type -> google.protobuf.Empty
+	35, // 40: proto.ProfileService.BinaryDump:input_type -> google.protobuf.Empty
+	35, // 41: proto.ProfileService.BinaryHash:input_type -> google.protobuf.Empty
+	10, // 42: proto.ProfileService.Set:input_type -> proto.SetProfileInputType
+	14, // 43: proto.ProfileService.SetMultiple:input_type -> proto.VariablesType
+	11, // 44: proto.ProfileService.Get:input_type -> proto.GetProfileInputType
+	12, // 45: proto.ProfileService.Reset:input_type -> proto.ResetProfileInputType
+	35, // 46: proto.ProfileService.ResetAll:input_type -> google.protobuf.Empty
+	7,  // 47: proto.ProfileService.SetMaxProcs:input_type -> proto.IntType
+	35, // 48: proto.ProfileService.GC:input_type -> google.protobuf.Empty
+	35, // 49: proto.ProfileService.FreeOSMemory:input_type -> google.protobuf.Empty
+	15, // 50: proto.ProfileService.LookupProfile:input_type -> proto.LookupProfileInputType
+	16, // 51: proto.ProfileService.NonLookupProfile:input_type -> proto.NonLookupProfileInputType
+	9,  // 52: proto.ProfileService.StopNonLookupProfile:input_type -> proto.NonLookupProfileType
+	17, // 53: proto.ProfileService.ContinuousProfile:input_type -> proto.ContinuousProfileInputType
+	18, // 54: proto.ProfileService.Snapshot:input_type -> proto.SnapshotInputType
+	6,  // 55: proto.ProfileService.Ping:output_type -> proto.StringType
+	27, // 56: proto.ProfileService.Version:output_type -> proto.VersionType
+	24, // 57: proto.ProfileService.GetInfo:output_type -> proto.InfoType
+	24, // 58: proto.ProfileService.WatchInfo:output_type -> proto.InfoType
+	26, // 59: proto.ProfileService.GetRuntimeMetrics:output_type -> proto.RuntimeMetricsType
+	5,  // 60: proto.ProfileService.BinaryDump:output_type -> proto.FileChunk
+	28, // 61: proto.ProfileService.BinaryHash:output_type -> proto.BinaryHashType
+	7,  // 62: proto.ProfileService.Set:output_type -> proto.IntType
+	14, // 63: proto.ProfileService.SetMultiple:output_type -> proto.VariablesType
+	7,  // 64: proto.ProfileService.Get:output_type -> proto.IntType
+	7,  // 65: proto.ProfileService.Reset:output_type -> proto.IntType
+	14, // 66: proto.ProfileService.ResetAll:output_type -> proto.VariablesType
+	7,  // 67: proto.ProfileService.SetMaxProcs:output_type -> proto.IntType
+	35, // 68: proto.ProfileService.GC:output_type -> google.protobuf.Empty
+	35, // 69: proto.ProfileService.FreeOSMemory:output_type -> google.protobuf.Empty
+	5,  // 70: proto.ProfileService.LookupProfile:output_type -> proto.FileChunk
+	5,  // 71: proto.ProfileService.NonLookupProfile:output_type -> proto.FileChunk
+	35, // 72: proto.ProfileService.StopNonLookupProfile:output_type -> google.protobuf.Empty
+	19, // 73: proto.ProfileService.ContinuousProfile:output_type -> proto.ProfileFrame
+	5,  // 74: proto.ProfileService.Snapshot:output_type -> proto.FileChunk
+	55, // [55:75] is the sub-list for method output_type
+	35, // [35:55] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_profile_proto_init() }
@@ -1615,6 +2690,18 @@ func file_profile_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_profile_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileMeta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FileChunk); i {
 			case 0:
 				return &v.state
@@ -1626,8 +2713,68 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StringType); i {
+		file_profile_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StringType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupProfileType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NonLookupProfileType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetProfileInputType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetProfileInputType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1638,8 +2785,8 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IntType); i {
+		file_profile_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetProfileInputType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1650,8 +2797,8 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LookupProfileType); i {
+		file_profile_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VariableValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1662,8 +2809,8 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NonLookupProfileType); i {
+		file_profile_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VariablesType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1674,8 +2821,8 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetProfileInputType); i {
+		file_profile_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupProfileInputType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1686,8 +2833,8 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResetProfileInputType); i {
+		file_profile_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NonLookupProfileInputType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1698,8 +2845,8 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LookupProfileInputType); i {
+		file_profile_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContinuousProfileInputType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1710,8 +2857,8 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NonLookupProfileInputType); i {
+		file_profile_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotInputType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1722,7 +2869,19 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_profile_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MemStats); i {
 			case 0:
 				return &v.state
@@ -1734,7 +2893,7 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_profile_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FileInfo); i {
 			case 0:
 				return &v.state
@@ -1746,7 +2905,7 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_profile_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IDName); i {
 			case 0:
 				return &v.state
@@ -1758,7 +2917,7 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_profile_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProcessStats); i {
 			case 0:
 				return &v.state
@@ -1770,7 +2929,7 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
-		file_profile_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_profile_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InfoType); i {
 			case 0:
 				return &v.state
@@ -1782,14 +2941,74 @@ func file_profile_proto_init() {
 				return nil
 			}
 		}
+		file_profile_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuntimeMetric); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuntimeMetricsType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BinaryHashType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_profile_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchInfoInputType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_profile_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   14,
+			NumEnums:      4,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1818,19 +3037,32 @@ const _ = grpc.SupportPackageIsVersion6
 type ProfileServiceClient interface {
 	// Test
 	Ping(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*StringType, error)
+	Version(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VersionType, error)
 	// Info
 	GetInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InfoType, error)
+	WatchInfo(ctx context.Context, in *WatchInfoInputType, opts ...grpc.CallOption) (ProfileService_WatchInfoClient, error)
+	GetRuntimeMetrics(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RuntimeMetricsType, error)
 	// BinaryDump
 	BinaryDump(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (ProfileService_BinaryDumpClient, error)
+	BinaryHash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*BinaryHashType, error)
 	// Variable
 	Set(ctx context.Context, in *SetProfileInputType, opts ...grpc.CallOption) (*IntType, error)
+	SetMultiple(ctx context.Context, in *VariablesType, opts ...grpc.CallOption) (*VariablesType, error)
+	Get(ctx context.Context, in *GetProfileInputType, opts ...grpc.CallOption) (*IntType, error)
+	Reset(ctx context.Context, in *ResetProfileInputType, opts ...grpc.CallOption) (*IntType, error)
+	ResetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VariablesType, error)
+	SetMaxProcs(ctx context.Context, in *IntType, opts ...grpc.CallOption) (*IntType, error)
 	// GC
 	GC(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	FreeOSMemory(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 	// Lookup Profile
 	LookupProfile(ctx context.Context, in *LookupProfileInputType, opts ...grpc.CallOption) (ProfileService_LookupProfileClient, error)
 	// Non Lookup Profile
 	NonLookupProfile(ctx context.Context, in *NonLookupProfileInputType, opts ...grpc.CallOption) (ProfileService_NonLookupProfileClient, error)
 	StopNonLookupProfile(ctx context.Context, in *NonLookupProfileType, opts ...grpc.CallOption) (*empty.Empty, error)
+	ContinuousProfile(ctx context.Context, in *ContinuousProfileInputType, opts ...grpc.CallOption) (ProfileService_ContinuousProfileClient, error)
+	// Snapshot
+	Snapshot(ctx context.Context, in *SnapshotInputType, opts ...grpc.CallOption) (ProfileService_SnapshotClient, error)
 }
 
 type profileServiceClient struct {
@@ -1850,21 +3082,216 @@ func (c *profileServiceClient) Ping(ctx context.Context, in *empty.Empty, opts .
 	return out, nil
 }
 
+func (c *profileServiceClient) Version(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VersionType, error) {
+	out := new(VersionType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *profileServiceClient) GetInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InfoType, error) {
 	out := new(InfoType)
 	err := c.cc.Invoke(ctx, "/proto.ProfileService/GetInfo", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *profileServiceClient) WatchInfo(ctx context.Context, in *WatchInfoInputType, opts ...grpc.CallOption) (ProfileService_WatchInfoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[0], "/proto.ProfileService/WatchInfo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &profileServiceWatchInfoClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProfileService_WatchInfoClient interface {
+	Recv() (*InfoType, error)
+	grpc.ClientStream
+}
+
+type profileServiceWatchInfoClient struct {
+	grpc.ClientStream
+}
+
+func (x *profileServiceWatchInfoClient) Recv() (*InfoType, error) {
+	m := new(InfoType)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *profileServiceClient) GetRuntimeMetrics(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RuntimeMetricsType, error) {
+	out := new(RuntimeMetricsType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/GetRuntimeMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) BinaryDump(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (ProfileService_BinaryDumpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[1], "/proto.ProfileService/BinaryDump", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &profileServiceBinaryDumpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProfileService_BinaryDumpClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type profileServiceBinaryDumpClient struct {
+	grpc.ClientStream
+}
+
+func (x *profileServiceBinaryDumpClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *profileServiceClient) BinaryHash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*BinaryHashType, error) {
+	out := new(BinaryHashType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/BinaryHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) Set(ctx context.Context, in *SetProfileInputType, opts ...grpc.CallOption) (*IntType, error) {
+	out := new(IntType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) SetMultiple(ctx context.Context, in *VariablesType, opts ...grpc.CallOption) (*VariablesType, error) {
+	out := new(VariablesType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/SetMultiple", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) Get(ctx context.Context, in *GetProfileInputType, opts ...grpc.CallOption) (*IntType, error) {
+	out := new(IntType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) Reset(ctx context.Context, in *ResetProfileInputType, opts ...grpc.CallOption) (*IntType, error) {
+	out := new(IntType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/Reset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) ResetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VariablesType, error) {
+	out := new(VariablesType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/ResetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) SetMaxProcs(ctx context.Context, in *IntType, opts ...grpc.CallOption) (*IntType, error) {
+	out := new(IntType)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/SetMaxProcs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) GC(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/GC", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) FreeOSMemory(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/FreeOSMemory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) LookupProfile(ctx context.Context, in *LookupProfileInputType, opts ...grpc.CallOption) (ProfileService_LookupProfileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[2], "/proto.ProfileService/LookupProfile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &profileServiceLookupProfileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProfileService_LookupProfileClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type profileServiceLookupProfileClient struct {
+	grpc.ClientStream
+}
+
+func (x *profileServiceLookupProfileClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *profileServiceClient) BinaryDump(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (ProfileService_BinaryDumpClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[0], "/proto.ProfileService/BinaryDump", opts...)
+func (c *profileServiceClient) NonLookupProfile(ctx context.Context, in *NonLookupProfileInputType, opts ...grpc.CallOption) (ProfileService_NonLookupProfileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[3], "/proto.ProfileService/NonLookupProfile", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &profileServiceBinaryDumpClient{stream}
+	x := &profileServiceNonLookupProfileClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1874,16 +3301,16 @@ func (c *profileServiceClient) BinaryDump(ctx context.Context, in *empty.Empty,
 	return x, nil
 }
 
-type ProfileService_BinaryDumpClient interface {
+type ProfileService_NonLookupProfileClient interface {
 	Recv() (*FileChunk, error)
 	grpc.ClientStream
 }
 
-type profileServiceBinaryDumpClient struct {
+type profileServiceNonLookupProfileClient struct {
 	grpc.ClientStream
 }
 
-func (x *profileServiceBinaryDumpClient) Recv() (*FileChunk, error) {
+func (x *profileServiceNonLookupProfileClient) Recv() (*FileChunk, error) {
 	m := new(FileChunk)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -1891,30 +3318,21 @@ func (x *profileServiceBinaryDumpClient) Recv() (*FileChunk, error) {
 	return m, nil
 }
 
-func (c *profileServiceClient) Set(ctx context.Context, in *SetProfileInputType, opts ...grpc.CallOption) (*IntType, error) {
-	out := new(IntType)
-	err := c.cc.Invoke(ctx, "/proto.ProfileService/Set", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *profileServiceClient) GC(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *profileServiceClient) StopNonLookupProfile(ctx context.Context, in *NonLookupProfileType, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/proto.ProfileService/GC", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/proto.ProfileService/StopNonLookupProfile", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *profileServiceClient) LookupProfile(ctx context.Context, in *LookupProfileInputType, opts ...grpc.CallOption) (ProfileService_LookupProfileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[1], "/proto.ProfileService/LookupProfile", opts...)
+func (c *profileServiceClient) ContinuousProfile(ctx context.Context, in *ContinuousProfileInputType, opts ...grpc.CallOption) (ProfileService_ContinuousProfileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[4], "/proto.ProfileService/ContinuousProfile", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &profileServiceLookupProfileClient{stream}
+	x := &profileServiceContinuousProfileClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1924,29 +3342,29 @@ func (c *profileServiceClient) LookupProfile(ctx context.Context, in *LookupProf
 	return x, nil
 }
 
-type ProfileService_LookupProfileClient interface {
-	Recv() (*FileChunk, error)
+type ProfileService_ContinuousProfileClient interface {
+	Recv() (*ProfileFrame, error)
 	grpc.ClientStream
 }
 
-type profileServiceLookupProfileClient struct {
+type profileServiceContinuousProfileClient struct {
 	grpc.ClientStream
 }
 
-func (x *profileServiceLookupProfileClient) Recv() (*FileChunk, error) {
-	m := new(FileChunk)
+func (x *profileServiceContinuousProfileClient) Recv() (*ProfileFrame, error) {
+	m := new(ProfileFrame)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *profileServiceClient) NonLookupProfile(ctx context.Context, in *NonLookupProfileInputType, opts ...grpc.CallOption) (ProfileService_NonLookupProfileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[2], "/proto.ProfileService/NonLookupProfile", opts...)
+func (c *profileServiceClient) Snapshot(ctx context.Context, in *SnapshotInputType, opts ...grpc.CallOption) (ProfileService_SnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ProfileService_serviceDesc.Streams[5], "/proto.ProfileService/Snapshot", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &profileServiceNonLookupProfileClient{stream}
+	x := &profileServiceSnapshotClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1956,16 +3374,16 @@ func (c *profileServiceClient) NonLookupProfile(ctx context.Context, in *NonLook
 	return x, nil
 }
 
-type ProfileService_NonLookupProfileClient interface {
+type ProfileService_SnapshotClient interface {
 	Recv() (*FileChunk, error)
 	grpc.ClientStream
 }
 
-type profileServiceNonLookupProfileClient struct {
+type profileServiceSnapshotClient struct {
 	grpc.ClientStream
 }
 
-func (x *profileServiceNonLookupProfileClient) Recv() (*FileChunk, error) {
+func (x *profileServiceSnapshotClient) Recv() (*FileChunk, error) {
 	m := new(FileChunk)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -1973,32 +3391,36 @@ func (x *profileServiceNonLookupProfileClient) Recv() (*FileChunk, error) {
 	return m, nil
 }
 
-func (c *profileServiceClient) StopNonLookupProfile(ctx context.Context, in *NonLookupProfileType, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/proto.ProfileService/StopNonLookupProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
 // ProfileServiceServer is the server API for ProfileService service.
 type ProfileServiceServer interface {
 	// Test
 	Ping(context.Context, *empty.Empty) (*StringType, error)
+	Version(context.Context, *empty.Empty) (*VersionType, error)
 	// Info
 	GetInfo(context.Context, *empty.Empty) (*InfoType, error)
+	WatchInfo(*WatchInfoInputType, ProfileService_WatchInfoServer) error
+	GetRuntimeMetrics(context.Context, *empty.Empty) (*RuntimeMetricsType, error)
 	// BinaryDump
 	BinaryDump(*empty.Empty, ProfileService_BinaryDumpServer) error
+	BinaryHash(context.Context, *empty.Empty) (*BinaryHashType, error)
 	// Variable
 	Set(context.Context, *SetProfileInputType) (*IntType, error)
+	SetMultiple(context.Context, *VariablesType) (*VariablesType, error)
+	Get(context.Context, *GetProfileInputType) (*IntType, error)
+	Reset(context.Context, *ResetProfileInputType) (*IntType, error)
+	ResetAll(context.Context, *empty.Empty) (*VariablesType, error)
+	SetMaxProcs(context.Context, *IntType) (*IntType, error)
 	// GC
 	GC(context.Context, *empty.Empty) (*empty.Empty, error)
+	FreeOSMemory(context.Context, *empty.Empty) (*empty.Empty, error)
 	// Lookup Profile
 	LookupProfile(*LookupProfileInputType, ProfileService_LookupProfileServer) error
 	// Non Lookup Profile
 	NonLookupProfile(*NonLookupProfileInputType, ProfileService_NonLookupProfileServer) error
 	StopNonLookupProfile(context.Context, *NonLookupProfileType) (*empty.Empty, error)
+	ContinuousProfile(*ContinuousProfileInputType, ProfileService_ContinuousProfileServer) error
+	// Snapshot
+	Snapshot(*SnapshotInputType, ProfileService_SnapshotServer) error
 }
 
 // UnimplementedProfileServiceServer can be embedded to have forward compatible implementations.
@@ -2008,18 +3430,48 @@ type UnimplementedProfileServiceServer struct {
 func (*UnimplementedProfileServiceServer) Ping(context.Context, *empty.Empty) (*StringType, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (*UnimplementedProfileServiceServer) Version(context.Context, *empty.Empty) (*VersionType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
 func (*UnimplementedProfileServiceServer) GetInfo(context.Context, *empty.Empty) (*InfoType, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
 }
+func (*UnimplementedProfileServiceServer) WatchInfo(*WatchInfoInputType, ProfileService_WatchInfoServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInfo not implemented")
+}
+func (*UnimplementedProfileServiceServer) GetRuntimeMetrics(context.Context, *empty.Empty) (*RuntimeMetricsType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRuntimeMetrics not implemented")
+}
 func (*UnimplementedProfileServiceServer) BinaryDump(*empty.Empty, ProfileService_BinaryDumpServer) error {
 	return status.Errorf(codes.Unimplemented, "method BinaryDump not implemented")
 }
+func (*UnimplementedProfileServiceServer) BinaryHash(context.Context, *empty.Empty) (*BinaryHashType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BinaryHash not implemented")
+}
 func (*UnimplementedProfileServiceServer) Set(context.Context, *SetProfileInputType) (*IntType, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
 }
+func (*UnimplementedProfileServiceServer) SetMultiple(context.Context, *VariablesType) (*VariablesType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMultiple not implemented")
+}
+func (*UnimplementedProfileServiceServer) Get(context.Context, *GetProfileInputType) (*IntType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedProfileServiceServer) Reset(context.Context, *ResetProfileInputType) (*IntType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (*UnimplementedProfileServiceServer) ResetAll(context.Context, *empty.Empty) (*VariablesType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetAll not implemented")
+}
+func (*UnimplementedProfileServiceServer) SetMaxProcs(context.Context, *IntType) (*IntType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaxProcs not implemented")
+}
 func (*UnimplementedProfileServiceServer) GC(context.Context, *empty.Empty) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GC not implemented")
 }
+func (*UnimplementedProfileServiceServer) FreeOSMemory(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreeOSMemory not implemented")
+}
 func (*UnimplementedProfileServiceServer) LookupProfile(*LookupProfileInputType, ProfileService_LookupProfileServer) error {
 	return status.Errorf(codes.Unimplemented, "method LookupProfile not implemented")
 }
@@ -2029,6 +3481,12 @@ func (*UnimplementedProfileServiceServer) NonLookupProfile(*NonLookupProfileInpu
 func (*UnimplementedProfileServiceServer) StopNonLookupProfile(context.Context, *NonLookupProfileType) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopNonLookupProfile not implemented")
 }
+func (*UnimplementedProfileServiceServer) ContinuousProfile(*ContinuousProfileInputType, ProfileService_ContinuousProfileServer) error {
+	return status.Errorf(codes.Unimplemented, "method ContinuousProfile not implemented")
+}
+func (*UnimplementedProfileServiceServer) Snapshot(*SnapshotInputType, ProfileService_SnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
 
 func RegisterProfileServiceServer(s *grpc.Server, srv ProfileServiceServer) {
 	s.RegisterService(&_ProfileService_serviceDesc, srv)
@@ -2052,6 +3510,24 @@ func _ProfileService_Ping_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).Version(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -2070,6 +3546,45 @@ func _ProfileService_GetInfo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_WatchInfo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInfoInputType)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfileServiceServer).WatchInfo(m, &profileServiceWatchInfoServer{stream})
+}
+
+type ProfileService_WatchInfoServer interface {
+	Send(*InfoType) error
+	grpc.ServerStream
+}
+
+type profileServiceWatchInfoServer struct {
+	grpc.ServerStream
+}
+
+func (x *profileServiceWatchInfoServer) Send(m *InfoType) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProfileService_GetRuntimeMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetRuntimeMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/GetRuntimeMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetRuntimeMetrics(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_BinaryDump_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(empty.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2091,6 +3606,24 @@ func (x *profileServiceBinaryDumpServer) Send(m *FileChunk) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ProfileService_BinaryHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).BinaryHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/BinaryHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).BinaryHash(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetProfileInputType)
 	if err := dec(in); err != nil {
@@ -2109,6 +3642,96 @@ func _ProfileService_Set_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_SetMultiple_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariablesType)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).SetMultiple(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/SetMultiple",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).SetMultiple(ctx, req.(*VariablesType))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileInputType)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).Get(ctx, req.(*GetProfileInputType))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetProfileInputType)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/Reset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).Reset(ctx, req.(*ResetProfileInputType))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_ResetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).ResetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/ResetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).ResetAll(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_SetMaxProcs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntType)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).SetMaxProcs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/SetMaxProcs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).SetMaxProcs(ctx, req.(*IntType))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_GC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -2127,6 +3750,24 @@ func _ProfileService_GC_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_FreeOSMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).FreeOSMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProfileService/FreeOSMemory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).FreeOSMemory(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_LookupProfile_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(LookupProfileInputType)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2187,6 +3828,48 @@ func _ProfileService_StopNonLookupProfile_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_ContinuousProfile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContinuousProfileInputType)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfileServiceServer).ContinuousProfile(m, &profileServiceContinuousProfileServer{stream})
+}
+
+type ProfileService_ContinuousProfileServer interface {
+	Send(*ProfileFrame) error
+	grpc.ServerStream
+}
+
+type profileServiceContinuousProfileServer struct {
+	grpc.ServerStream
+}
+
+func (x *profileServiceContinuousProfileServer) Send(m *ProfileFrame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProfileService_Snapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotInputType)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfileServiceServer).Snapshot(m, &profileServiceSnapshotServer{stream})
+}
+
+type ProfileService_SnapshotServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type profileServiceSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *profileServiceSnapshotServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _ProfileService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.ProfileService",
 	HandlerType: (*ProfileServiceServer)(nil),
@@ -2195,24 +3878,65 @@ var _ProfileService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _ProfileService_Ping_Handler,
 		},
+		{
+			MethodName: "Version",
+			Handler:    _ProfileService_Version_Handler,
+		},
 		{
 			MethodName: "GetInfo",
 			Handler:    _ProfileService_GetInfo_Handler,
 		},
+		{
+			MethodName: "GetRuntimeMetrics",
+			Handler:    _ProfileService_GetRuntimeMetrics_Handler,
+		},
+		{
+			MethodName: "BinaryHash",
+			Handler:    _ProfileService_BinaryHash_Handler,
+		},
 		{
 			MethodName: "Set",
 			Handler:    _ProfileService_Set_Handler,
 		},
+		{
+			MethodName: "SetMultiple",
+			Handler:    _ProfileService_SetMultiple_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ProfileService_Get_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _ProfileService_Reset_Handler,
+		},
+		{
+			MethodName: "ResetAll",
+			Handler:    _ProfileService_ResetAll_Handler,
+		},
+		{
+			MethodName: "SetMaxProcs",
+			Handler:    _ProfileService_SetMaxProcs_Handler,
+		},
 		{
 			MethodName: "GC",
 			Handler:    _ProfileService_GC_Handler,
 		},
+		{
+			MethodName: "FreeOSMemory",
+			Handler:    _ProfileService_FreeOSMemory_Handler,
+		},
 		{
 			MethodName: "StopNonLookupProfile",
 			Handler:    _ProfileService_StopNonLookupProfile_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchInfo",
+			Handler:       _ProfileService_WatchInfo_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "BinaryDump",
 			Handler:       _ProfileService_BinaryDump_Handler,
@@ -2228,6 +3952,16 @@ var _ProfileService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ProfileService_NonLookupProfile_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ContinuousProfile",
+			Handler:       _ProfileService_ContinuousProfile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Snapshot",
+			Handler:       _ProfileService_Snapshot_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "profile.proto",
 }
@@ -0,0 +1,51 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// rateVariables maps the lookup profiles to the variable controlling their sampling rate
+var rateVariables = map[LookupType]Variable{
+	HeapType:   MemProfRate,
+	AllocsType: MemProfRate,
+	BlockType:  BlockProfileRate,
+	MutexType:  MutexProfileFraction,
+}
+
+// restoreTimeout bounds restoring a variable after `ProfileWithRate()`, which is done even if its context is done
+const restoreTimeout = 10 * time.Second
+
+// ProfileWithRate function will set the variable controlling the sampling rate of the lookup profile t (BlockProfileRate
+// for block, MutexProfileFraction for mutex and MemProfRate for heap and allocs) to rate, wait for d, write the profile
+// to writer and set the variable back to its previous value. The variable is restored even if waiting or collecting the
+// profile fails
+func (client *Client) ProfileWithRate(ctx context.Context, t LookupType, rate int, d time.Duration, writer io.Writer, options ...LookupOption) (err error) {
+	v, ok := rateVariables[t]
+	if !ok {
+		return fmt.Errorf("profile type %s has no sampling rate", t)
+	}
+
+	prev, err := client.set(ctx, v, rate)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		restoreCtx, cancel := context.WithTimeout(context.Background(), restoreTimeout)
+		defer cancel()
+		if _, restoreErr := client.set(restoreCtx, v, prev); err == nil {
+			err = restoreErr
+		}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+	return client.LookupProfile(ctx, t, writer, options...)
+}
@@ -0,0 +1,62 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestProfileWithRate(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	const prev = 0
+	if _, err := client.Set(ctx, BlockProfileRate, prev); err != nil {
+		t.Fatal(err)
+	}
+
+	// checkRestored will fail the test if the block rate is not set back to its previous value
+	checkRestored := func(name string) {
+		t.Helper()
+		got, err := client.Get(ctx, BlockProfileRate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != prev {
+			t.Errorf("block rate after %s: got %d, want %d", name, got, prev)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := client.ProfileWithRate(ctx, BlockType, 1, 50*time.Millisecond, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pprofile.Parse(&buf); err != nil {
+		t.Errorf("block profile: %v", err)
+	}
+	checkRestored("a collection")
+
+	// The agent rejects the unknown sample type, so the collection fails after the rate is set
+	err = client.ProfileWithRate(ctx, BlockType, 1, 10*time.Millisecond, ioutil.Discard, LookupSampleType("missing"))
+	if err == nil {
+		t.Error("collection of a missing sample type: got no error")
+	}
+	checkRestored("a failed collection")
+
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = client.ProfileWithRate(cancelled, BlockType, 1, time.Minute, ioutil.Discard)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("collection beyond the deadline: got %v, want %v", err, context.DeadlineExceeded)
+	}
+	checkRestored("a cancelled collection")
+
+	if err = client.ProfileWithRate(ctx, GoRoutineType, 1, 0, ioutil.Discard); err == nil {
+		t.Error("collection of a profile without a rate: got no error")
+	}
+}
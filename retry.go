@@ -0,0 +1,102 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// idempotentMethods are the unary RPCs without side effects, which are safe to retry
+var idempotentMethods = map[string]bool{
+	"/proto.ProfileService/Ping":              true,
+	"/proto.ProfileService/Version":           true,
+	"/proto.ProfileService/GetInfo":           true,
+	"/proto.ProfileService/Get":               true,
+	"/proto.ProfileService/BinaryHash":        true,
+	"/proto.ProfileService/GetRuntimeMetrics": true,
+}
+
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// DialRetry function will create a GRPC Profile Client Dial option to retry the RPCs without side effects (`Ping()`,
+// `GetInfo()`, `Get()`, `BinaryHash()`, ...) and the lookup profiles up to maxAttempts times when they fail with
+// `codes.Unavailable` or `codes.DeadlineExceeded`. The wait between attempts starts at backoff and doubles every time.
+// A lookup profile is retried only if nothing was written yet. Non lookup profiles and RPCs changing the agent are
+// never retried
+func DialRetry(maxAttempts int, backoff time.Duration) *DialOption {
+	if maxAttempts < 1 {
+		return &DialOption{error: errors.New("maximum attempts must be at least 1")}
+	}
+	if backoff < 0 {
+		return &DialOption{error: errors.New("backoff can not be negative")}
+	}
+	policy := &retryPolicy{maxAttempts: maxAttempts, backoff: backoff}
+	return &DialOption{
+		option: grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if !idempotentMethods[method] {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+			return policy.do(ctx, func() error {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}, nil)
+		}),
+		apply: func(client *Client) {
+			client.retry = policy
+		},
+	}
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// do will call fn until it succeeds, fails with an error which is not retryable, canRetry returns false or the
+// attempts are exhausted. A nil policy calls fn once
+func (policy *retryPolicy) do(ctx context.Context, fn func() error, canRetry func() bool) error {
+	if policy == nil {
+		return fn()
+	}
+	wait := policy.backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= policy.maxAttempts || !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+		if canRetry != nil && !canRetry() {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// countingWriter counts the bytes written to writer
+type countingWriter struct {
+	writer io.Writer
+	n      int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
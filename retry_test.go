@@ -0,0 +1,97 @@
+package profile
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/agent"
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyServer is an agent whose GetInfo, LookupProfile and NonLookupProfile RPCs fail with `codes.Unavailable` on
+// their first call
+type flakyServer struct {
+	*agent.Agent
+	calls map[string]int
+	mutex sync.Mutex
+}
+
+// fail will count a call of method and return the error of a failing first call
+func (server *flakyServer) fail(method string) error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.calls[method]++
+	if server.calls[method] == 1 {
+		return status.Error(codes.Unavailable, "transient failure")
+	}
+	return nil
+}
+
+func (server *flakyServer) GetInfo(ctx context.Context, in *empty.Empty) (*proto.InfoType, error) {
+	if err := server.fail("GetInfo"); err != nil {
+		return nil, err
+	}
+	return server.Agent.GetInfo(ctx, in)
+}
+
+func (server *flakyServer) LookupProfile(in *proto.LookupProfileInputType, stream proto.ProfileService_LookupProfileServer) error {
+	if err := server.fail("LookupProfile"); err != nil {
+		return err
+	}
+	return server.Agent.LookupProfile(in, stream)
+}
+
+func (server *flakyServer) NonLookupProfile(in *proto.NonLookupProfileInputType, stream proto.ProfileService_NonLookupProfileServer) error {
+	if err := server.fail("NonLookupProfile"); err != nil {
+		return err
+	}
+	return server.Agent.NonLookupProfile(in, stream)
+}
+
+func TestDialRetry(t *testing.T) {
+	profileAgent, err := agent.NewAgent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	flaky := &flakyServer{Agent: profileAgent, calls: make(map[string]int)}
+	server := grpc.NewServer()
+	proto.RegisterProfileServiceServer(server, flaky)
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listen)
+	defer server.Stop()
+
+	ctx := context.Background()
+	client, err := Dial(ctx, listen.Addr().String(), WithInsecure(), WithDialOption(DialRetry(3, 10*time.Millisecond)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Stop()
+
+	if _, err = client.GetInfo(ctx); err != nil {
+		t.Errorf("GetInfo failing once: %v", err)
+	}
+	if err = client.LookupProfile(ctx, HeapType, ioutil.Discard); err != nil {
+		t.Errorf("lookup profile failing once: %v", err)
+	}
+	if code := status.Code(client.NonLookupProfile(ctx, CPUType, 100*time.Millisecond, ioutil.Discard)); code != codes.Unavailable {
+		t.Errorf("non lookup profile failing once: got %v, want %v without a retry", code, codes.Unavailable)
+	}
+	flaky.mutex.Lock()
+	defer flaky.mutex.Unlock()
+	for method, want := range map[string]int{"GetInfo": 2, "LookupProfile": 2, "NonLookupProfile": 1} {
+		if got := flaky.calls[method]; got != want {
+			t.Errorf("calls of %s: got %d, want %d", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+// Sample will store a stack of a profile with its value. The stack lists function names from the innermost frame to
+// the outermost one
+type Sample struct {
+	Stack []string
+	Value int64
+}
+
+// sampleIndex will return the index of the default sample type of a profile. Like `go tool pprof` it falls back to
+// the last sample type
+func sampleIndex(p *pprofile.Profile) int {
+	for i, sampleType := range p.SampleType {
+		if sampleType.Type == p.DefaultSampleType {
+			return i
+		}
+	}
+	return len(p.SampleType) - 1
+}
+
+// sampleStack will return the function names of a sample, innermost first
+func sampleStack(sample *pprofile.Sample) []string {
+	var stack []string
+	for _, location := range sample.Location {
+		for _, line := range location.Line {
+			if line.Function != nil {
+				stack = append(stack, line.Function.Name)
+			}
+		}
+	}
+	return stack
+}
+
+// LookupSamples will run a profile for lookup pprof type and return its samples as plain stacks and values, so it can
+// be analysed without depending on the pprof profile format. The value is the one of the default sample type of the
+// profile (e.g. inuse_space for heap)
+func (client *Client) LookupSamples(ctx context.Context, t LookupType) ([]Sample, error) {
+	var buf bytes.Buffer
+	if err := client.LookupProfile(ctx, t, &buf); err != nil {
+		return nil, err
+	}
+	p, err := pprofile.Parse(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	index := sampleIndex(p)
+	if index < 0 {
+		return nil, errors.New("profile has no sample types")
+	}
+	samples := make([]Sample, 0, len(p.Sample))
+	for _, sample := range p.Sample {
+		samples = append(samples, Sample{
+			Stack: sampleStack(sample),
+			Value: sample.Value[index],
+		})
+	}
+	return samples, nil
+}
@@ -0,0 +1,88 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestSampleStack(t *testing.T) {
+	want := []Sample{
+		{Stack: []string{"main.leaf", "main.handle", "main.main"}, Value: 3},
+		{Stack: []string{"main.handle", "main.main"}, Value: 5},
+	}
+	p, err := pprofile.ParseData(syntheticProfile(t, want...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := sampleIndex(p)
+	if index != 0 {
+		t.Fatalf("index of the only sample type: got %d, want 0", index)
+	}
+	for i, sample := range p.Sample {
+		got := Sample{Stack: sampleStack(sample), Value: sample.Value[index]}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("sample %d: got %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	// Without a default sample type the last one is used
+	p.SampleType = append(p.SampleType, &pprofile.ValueType{Type: "nanoseconds", Unit: "count"})
+	if index = sampleIndex(p); index != 1 {
+		t.Errorf("index without a default sample type: got %d, want the last one, 1", index)
+	}
+	p.DefaultSampleType = "samples"
+	if index = sampleIndex(p); index != 0 {
+		t.Errorf("index of the default sample type: got %d, want 0", index)
+	}
+}
+
+func TestLookupSamples(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	// The heap profile only changes at a GC, so both collections see the same profile
+	defer debug.SetGCPercent(debug.SetGCPercent(-1))
+	runtime.GC()
+
+	samples, err := client.LookupSamples(ctx, HeapType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = client.LookupProfile(ctx, HeapType, &buf); err != nil {
+		t.Fatal(err)
+	}
+	p, err := pprofile.Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := -1
+	for i, sampleType := range p.SampleType {
+		if sampleType.Type == "inuse_space" {
+			index = i
+		}
+	}
+	if index < 0 {
+		t.Fatalf("heap profile has no inuse_space sample type: %v", p.SampleType)
+	}
+
+	var got, want int64
+	for _, sample := range samples {
+		if len(sample.Stack) == 0 {
+			t.Errorf("sample of value %d has no stack", sample.Value)
+		}
+		got += sample.Value
+	}
+	for _, sample := range p.Sample {
+		want += sample.Value[index]
+	}
+	if got != want {
+		t.Errorf("total of the heap samples: got %d, want the inuse_space total %d", got, want)
+	}
+}
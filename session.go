@@ -0,0 +1,190 @@
+package profile
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+)
+
+const sessionManifestName = "manifest.json"
+
+// SessionEntry will store informarion about a profile recorded in a session archive
+type SessionEntry struct {
+	Index int
+	Name  string
+	Type  string
+	Time  time.Time
+	Size  int64
+}
+
+// SessionRecorder will record every profile collected through it into a single tar archive, so one investigation
+// produces one portable file. Entries are appended as they are collected and a manifest listing all of them is
+// written when the recorder is closed. We can create a instance of the recorder using `NewSessionRecorder()` function
+type SessionRecorder struct {
+	client  *Client
+	file    *os.File
+	archive *tar.Writer
+	entries []SessionEntry
+	mutex   sync.Mutex
+}
+
+// NewSessionRecorder function will create a SessionRecorder writing into the archive file at path
+func NewSessionRecorder(client *Client, path string) (*SessionRecorder, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionRecorder{
+		client:  client,
+		file:    file,
+		archive: tar.NewWriter(file),
+	}, nil
+}
+
+func (recorder *SessionRecorder) record(profileType, extension string, collect func(io.Writer) error) error {
+	var buf bytes.Buffer
+	collected := time.Now()
+	if err := collect(&buf); err != nil {
+		return err
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	entry := SessionEntry{
+		Index: len(recorder.entries),
+		Type:  profileType,
+		Time:  collected,
+		Size:  int64(buf.Len()),
+	}
+	entry.Name = fmt.Sprintf("%04d-%s%s", entry.Index, profileType, extension)
+	err := recorder.archive.WriteHeader(&tar.Header{
+		Name:    entry.Name,
+		Mode:    0600,
+		Size:    entry.Size,
+		ModTime: entry.Time,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = buf.WriteTo(recorder.archive); err != nil {
+		return err
+	}
+	if err = recorder.archive.Flush(); err != nil {
+		return err
+	}
+	recorder.entries = append(recorder.entries, entry)
+	return nil
+}
+
+// LookupProfile will run a profile for lookup pprof type and record it in the session
+func (recorder *SessionRecorder) LookupProfile(ctx context.Context, t LookupType, options ...LookupOption) error {
+	input := &proto.LookupProfileInputType{}
+	for _, option := range options {
+		option(input)
+	}
+	extension := ".pb.gz"
+	if input.Debug != 0 {
+		extension = ".txt"
+	}
+	return recorder.record(t.String(), extension, func(writer io.Writer) error {
+		return recorder.client.LookupProfile(ctx, t, writer, options...)
+	})
+}
+
+// NonLookupProfile will run a profile for non lookup pprof type and record it in the session
+func (recorder *SessionRecorder) NonLookupProfile(ctx context.Context, t NonLookupType, d time.Duration) error {
+	extension := ".pb.gz"
+	if t == TraceType {
+		extension = ".trace"
+	}
+	return recorder.record(t.String(), extension, func(writer io.Writer) error {
+		return recorder.client.NonLookupProfile(ctx, t, d, writer)
+	})
+}
+
+// Entries function will return the profiles recorded so far
+func (recorder *SessionRecorder) Entries() []SessionEntry {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]SessionEntry(nil), recorder.entries...)
+}
+
+// Close function will write the manifest and close the session archive
+func (recorder *SessionRecorder) Close() (err error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	defer func() {
+		if closeErr := recorder.file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	manifest, err := json.MarshalIndent(recorder.entries, "", "  ")
+	if err != nil {
+		return
+	}
+	err = recorder.archive.WriteHeader(&tar.Header{
+		Name:    sessionManifestName,
+		Mode:    0600,
+		Size:    int64(len(manifest)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return
+	}
+	if _, err = recorder.archive.Write(manifest); err != nil {
+		return
+	}
+	return recorder.archive.Close()
+}
+
+// ReadSessionManifest function will read the list of profiles recorded in a session archive
+func ReadSessionManifest(reader io.Reader) (entries []SessionEntry, err error) {
+	archive := tar.NewReader(reader)
+	for {
+		var header *tar.Header
+		header, err = archive.Next()
+		if err == io.EOF {
+			return nil, errors.New("session manifest not found")
+		}
+		if err != nil {
+			return
+		}
+		if header.Name == sessionManifestName {
+			err = json.NewDecoder(archive).Decode(&entries)
+			return
+		}
+	}
+}
+
+// ExtractSessionEntry function will write the profile recorded at index in a session archive into writer
+func ExtractSessionEntry(reader io.Reader, index int, writer io.Writer) error {
+	archive := tar.NewReader(reader)
+	for i := 0; ; {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return fmt.Errorf("session entry %d not found", index)
+		}
+		if err != nil {
+			return err
+		}
+		if header.Name == sessionManifestName {
+			continue
+		}
+		if i == index {
+			_, err = io.Copy(writer, archive)
+			return err
+		}
+		i++
+	}
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestSessionRecorder(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "session.tar")
+
+	recorder, err := NewSessionRecorder(client, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = recorder.LookupProfile(ctx, HeapType); err != nil {
+		t.Fatal(err)
+	}
+	if err = recorder.LookupProfile(ctx, GoRoutineType, LookupDebug(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err = recorder.NonLookupProfile(ctx, CPUType, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err = recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewSessionRecorder(client, path); err == nil {
+		t.Error("recorder over an existing archive: got no error")
+	}
+
+	archive, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadSessionManifest(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ name, profileType string }{
+		{"0000-heap.pb.gz", "heap"},
+		{"0001-goroutine.txt", "goroutine"},
+		{"0002-cpu.pb.gz", "cpu"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("session entries: got %+v, want %d", entries, len(want))
+	}
+	for i, entry := range entries {
+		if entry.Index != i || entry.Name != want[i].name || entry.Type != want[i].profileType {
+			t.Errorf("session entry %d: got %+v, want %s of type %s", i, entry, want[i].name, want[i].profileType)
+		}
+
+		var buf bytes.Buffer
+		err = ExtractSessionEntry(bytes.NewReader(archive), i, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(buf.Len()) != entry.Size {
+			t.Errorf("size of session entry %d: got %d, want %d", i, buf.Len(), entry.Size)
+		}
+		if strings.HasSuffix(entry.Name, ".txt") {
+			if !strings.Contains(buf.String(), "goroutine ") {
+				t.Errorf("session entry %d is not a goroutine dump", i)
+			}
+		} else if _, err = pprofile.Parse(&buf); err != nil {
+			t.Errorf("session entry %d: %v", i, err)
+		}
+	}
+	if err = ExtractSessionEntry(bytes.NewReader(archive), len(entries), &bytes.Buffer{}); err == nil {
+		t.Error("extracting a missing session entry: got no error")
+	}
+}
@@ -0,0 +1,32 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Snapshot function will stream a tar archive into writer with a CPU profile of cpuDur ("cpu.pb.gz"), a heap profile
+// ("heap.pb.gz"), a goroutine dump ("goroutine.txt") and the information about the agent ("info.json"), collected by
+// the agent in one request. The last entry, "manifest.json", lists the others as `agent.SnapshotEntry` values. Like
+// `NonLookupProfile()`, the call fails with `codes.DeadlineExceeded` if it takes longer than cpuDur and the slack set
+// with `DialProfileSlack()`, unless ctx has a deadline
+func (client *Client) Snapshot(ctx context.Context, cpuDur time.Duration, writer io.Writer) error {
+	if !client.HasFeature(FeatureSnapshot) {
+		return fmt.Errorf("agent does not support feature(s): %s", FeatureSnapshot)
+	}
+	if _, ok := ctx.Deadline(); !ok && client.profileSlack > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cpuDur+client.profileSlack)
+		defer cancel()
+	}
+	stream, err := client.service().Snapshot(ctx, &proto.SnapshotInputType{CPUDuration: ptypes.DurationProto(cpuDur)}, client.callOptions...)
+	if err != nil {
+		return err
+	}
+	return receiveFileChunk(writer, stream, false, nil)
+}
@@ -0,0 +1,87 @@
+package profile
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/agent"
+	pprofile "github.com/google/pprof/profile"
+)
+
+func TestSnapshot(t *testing.T) {
+	_, client := newTestClient(t)
+
+	var buf bytes.Buffer
+	err := client.Snapshot(context.Background(), 100*time.Millisecond, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := make(map[string][]byte)
+	archive := tar.NewReader(&buf)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(archive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[header.Name] = content
+	}
+	for _, name := range []string{"cpu.pb.gz", "heap.pb.gz", "goroutine.txt", "info.json", "manifest.json"} {
+		content, ok := entries[name]
+		if !ok {
+			t.Errorf("snapshot has no entry %s", name)
+		} else if len(content) == 0 {
+			t.Errorf("snapshot entry %s is empty", name)
+		}
+	}
+
+	var manifest []agent.SnapshotEntry
+	err = json.Unmarshal(entries["manifest.json"], &manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest) != len(entries)-1 {
+		t.Errorf("manifest entries: got %d, want the %d other entries of the snapshot", len(manifest), len(entries)-1)
+	}
+	for _, entry := range manifest {
+		content, ok := entries[entry.Name]
+		if !ok {
+			t.Errorf("manifest lists %s which is not in the snapshot", entry.Name)
+			continue
+		}
+		if entry.Size != int64(len(content)) {
+			t.Errorf("manifest size of %s: got %d, want %d", entry.Name, entry.Size, len(content))
+		}
+		if entry.Type == "" || entry.Time.IsZero() {
+			t.Errorf("manifest entry %s has no type or time: %+v", entry.Name, entry)
+		}
+		switch entry.Name {
+		case "cpu.pb.gz", "heap.pb.gz":
+			p, err := pprofile.ParseData(content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entry.Samples != len(p.Sample) {
+				t.Errorf("manifest samples of %s: got %d, want %d", entry.Name, entry.Samples, len(p.Sample))
+			}
+		case "goroutine.txt":
+			dumped := bytes.Count(content, []byte("]:\n"))
+			if entry.Samples != dumped {
+				t.Errorf("manifest samples of %s: got %d, want the %d dumped goroutines", entry.Name, entry.Samples, dumped)
+			}
+		}
+	}
+}
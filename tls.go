@@ -0,0 +1,20 @@
+package profile
+
+import (
+	"github.com/chanchal1987/grpc-profile/internal/tlsconfig"
+)
+
+// TLSOption will set a parameter of the TLS configuration used by the TLS Auth type GRPC Profile Client Dial options
+type TLSOption = tlsconfig.Option
+
+// TLSMinVersion function will create a TLSOption to set the minimum TLS version, e.g. `tls.VersionTLS13`.
+// The default is TLS 1.2
+func TLSMinVersion(version uint16) TLSOption {
+	return tlsconfig.MinVersion(version)
+}
+
+// TLSCipherSuites function will create a TLSOption to restrict the cipher suites allowed for TLS 1.2 and older. TLS
+// 1.3 cipher suites are not configurable
+func TLSCipherSuites(suites ...uint16) TLSOption {
+	return tlsconfig.CipherSuites(suites...)
+}
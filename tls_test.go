@@ -0,0 +1,130 @@
+package profile
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chanchal1987/grpc-profile/agent"
+)
+
+// writeTestCert will write a self-signed certificate for 127.0.0.1 and its key into the test directory. The
+// certificate is its own CA, so it can be used as the CA file too
+func writeTestCert(t *testing.T, name string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// startTestServer will start an agent created with options on a free local port and return its address. It is
+// stopped when the test ends
+func startTestServer(t *testing.T, options ...*agent.ServerOption) string {
+	t.Helper()
+	server, err := agent.NewAgent(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, _, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	return addr.String()
+}
+
+func TestTLSMinVersion(t *testing.T) {
+	certFile, keyFile := writeTestCert(t, "server")
+	addr := startTestServer(t, agent.ServerAuthTypeTLS(certFile, keyFile, agent.TLSMinVersion(tls.VersionTLS12)))
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	pem, err := os.ReadFile(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.AppendCertsFromPEM(pem)
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS10,
+		MaxVersion: tls.VersionTLS11,
+		NextProtos: []string{"h2"},
+	})
+	if err == nil {
+		conn.Close()
+		t.Fatal("TLS 1.1 client was accepted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := Dial(ctx, addr, WithTLS(certFile, TLSMinVersion(tls.VersionTLS12)))
+	if err != nil {
+		t.Fatalf("TLS 1.2 client: %v", err)
+	}
+	_ = client.Stop()
+}
+
+func TestMutualTLS(t *testing.T) {
+	serverCert, serverKey := writeTestCert(t, "server")
+	clientCert, clientKey := writeTestCert(t, "client")
+	addr := startTestServer(t, agent.ServerAuthTypeMutualTLS(serverCert, serverKey, clientCert))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := Dial(ctx, addr, WithTLS(serverCert))
+	if err == nil {
+		_ = client.Stop()
+		t.Fatal("client without a certificate was accepted")
+	}
+
+	client, err = Dial(ctx, addr, WithMutualTLS(clientCert, clientKey, serverCert))
+	if err != nil {
+		t.Fatalf("client with a valid certificate: %v", err)
+	}
+	_ = client.Stop()
+}
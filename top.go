@@ -0,0 +1,100 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+// TopEntry will store the flat and cumulative value of a function in a profile
+type TopEntry struct {
+	Function string
+	Flat     int64
+	Cum      int64
+	Unit     string
+}
+
+// Top function will parse a pprof profile from reader and return its n functions with the highest flat value of the
+// default sample type, like `go tool pprof -top`. All functions are returned if n is not positive
+func Top(reader io.Reader, n int) ([]TopEntry, error) {
+	p, err := pprofile.Parse(reader)
+	if err != nil {
+		return nil, err
+	}
+	index := sampleIndex(p)
+	if index < 0 {
+		return nil, errors.New("profile has no sample types")
+	}
+	unit := p.SampleType[index].Unit
+
+	entries := make(map[string]*TopEntry)
+	entry := func(function string) *TopEntry {
+		e, ok := entries[function]
+		if !ok {
+			e = &TopEntry{Function: function, Unit: unit}
+			entries[function] = e
+		}
+		return e
+	}
+	for _, sample := range p.Sample {
+		value := sample.Value[index]
+		stack := sampleStack(sample)
+		if len(stack) == 0 {
+			continue
+		}
+		entry(stack[0]).Flat += value
+		// Recursive functions are counted once per sample
+		seen := make(map[string]bool)
+		for _, function := range stack {
+			if !seen[function] {
+				seen[function] = true
+				entry(function).Cum += value
+			}
+		}
+	}
+
+	top := make([]TopEntry, 0, len(entries))
+	for _, e := range entries {
+		top = append(top, *e)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Flat != top[j].Flat {
+			return top[i].Flat > top[j].Flat
+		}
+		if top[i].Cum != top[j].Cum {
+			return top[i].Cum > top[j].Cum
+		}
+		return top[i].Function < top[j].Function
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top, nil
+}
+
+// ProfileTop will run a profile for lookup pprof type and return its top n functions, see `Top()`
+func (client *Client) ProfileTop(ctx context.Context, t LookupType, n int) ([]TopEntry, error) {
+	var buf bytes.Buffer
+	if err := client.LookupProfile(ctx, t, &buf); err != nil {
+		return nil, err
+	}
+	return Top(&buf, n)
+}
+
+// NonLookupProfileTop will run a profile for non lookup pprof type for duration d and return its top n functions, see
+// `Top()`. Execution traces are not pprof profiles, so only `CPUType` is supported
+func (client *Client) NonLookupProfileTop(ctx context.Context, t NonLookupType, d time.Duration, n int) ([]TopEntry, error) {
+	if t != CPUType {
+		return nil, errors.New("only CPU profiles can be summarised")
+	}
+	var buf bytes.Buffer
+	if err := client.NonLookupProfile(ctx, t, d, &buf); err != nil {
+		return nil, err
+	}
+	return Top(&buf, n)
+}
@@ -0,0 +1,92 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	pprofile "github.com/google/pprof/profile"
+)
+
+// syntheticProfile will return a pprof profile with the samples, one function per frame, and a single "samples"
+// sample type counted in "count"
+func syntheticProfile(t *testing.T, samples ...Sample) []byte {
+	t.Helper()
+	p := &pprofile.Profile{
+		SampleType: []*pprofile.ValueType{{Type: "samples", Unit: "count"}},
+		PeriodType: &pprofile.ValueType{Type: "samples", Unit: "count"},
+		Period:     1,
+	}
+	functions := make(map[string]*pprofile.Function)
+	locations := make(map[string]*pprofile.Location)
+	for _, sample := range samples {
+		s := &pprofile.Sample{Value: []int64{sample.Value}}
+		for _, name := range sample.Stack {
+			location, ok := locations[name]
+			if !ok {
+				function := &pprofile.Function{ID: uint64(len(functions) + 1), Name: name}
+				functions[name] = function
+				p.Function = append(p.Function, function)
+				location = &pprofile.Location{ID: uint64(len(locations) + 1), Line: []pprofile.Line{{Function: function}}}
+				locations[name] = location
+				p.Location = append(p.Location, location)
+			}
+			s.Location = append(s.Location, location)
+		}
+		p.Sample = append(p.Sample, s)
+	}
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestTop(t *testing.T) {
+	content := syntheticProfile(t,
+		Sample{Stack: []string{"hot", "handler", "main"}, Value: 70},
+		Sample{Stack: []string{"warm", "handler", "main"}, Value: 20},
+		Sample{Stack: []string{"hot", "worker", "main"}, Value: 5},
+		Sample{Stack: []string{"main"}, Value: 5},
+	)
+
+	top, err := Top(bytes.NewReader(content), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TopEntry{
+		{Function: "hot", Flat: 75, Cum: 75, Unit: "count"},
+		{Function: "warm", Flat: 20, Cum: 20, Unit: "count"},
+		{Function: "main", Flat: 5, Cum: 100, Unit: "count"},
+	}
+	if !reflect.DeepEqual(top, want) {
+		t.Errorf("top 3: got %+v, want %+v", top, want)
+	}
+
+	all, err := Top(bytes.NewReader(content), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 5 {
+		t.Errorf("all functions: got %d, want 5", len(all))
+	}
+}
+
+func TestProfileTop(t *testing.T) {
+	_, client := newTestClient(t)
+
+	top, err := client.ProfileTop(context.Background(), GoRoutineType, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) == 0 || len(top) > 5 {
+		t.Fatalf("top 5 goroutine functions: got %d entries", len(top))
+	}
+	for i := 1; i < len(top); i++ {
+		if top[i].Flat > top[i-1].Flat {
+			t.Errorf("top entries are not sorted by flat value: %+v", top)
+			break
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Features which can be reported by an agent
+const (
+	FeatureLookupDebug       = "lookup-debug"
+	FeatureCompression       = "compression"
+	FeatureContinuousProfile = "continuous-profile"
+	FeatureWatchInfo         = "watch-info"
+	FeatureBinaryHash        = "binary-hash"
+	FeatureGet               = "get"
+	FeatureGCPercent         = "gc-percent"
+	FeatureMaxProcs          = "max-procs"
+	FeatureFreeOSMemory      = "free-os-memory"
+	FeatureProfileMeta       = "profile-meta"
+	FeatureReset             = "reset"
+	FeatureRuntimeMetrics    = "runtime-metrics"
+	FeatureAllocs            = "allocs"
+	FeatureResetAll          = "reset-all"
+	FeatureSetMultiple       = "set-multiple"
+	FeatureSampleType        = "sample-type"
+	FeatureGCBefore          = "gc-before"
+	FeatureSnapshot          = "snapshot"
+	FeatureLabelFilter       = "label-filter"
+)
+
+// DialRequireFeatures function will create a GRPC Profile Client Dial option to fail `Connect` if the agent does not
+// support all of the features
+func DialRequireFeatures(features ...string) *DialOption {
+	return &DialOption{apply: func(client *Client) {
+		client.requiredFeatures = append(client.requiredFeatures, features...)
+	}}
+}
+
+// negotiate will fetch the version and the features of the agent. Agents without the `Version` RPC are reported with
+// an empty version and no features
+func (client *Client) negotiate() error {
+	version, err := client.service().Version(client.ctx, &empty.Empty{}, client.callOptions...)
+	if status.Code(err) == codes.Unimplemented {
+		version, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	client.agentVersion = version.GetVersion()
+	client.agentFeatures = make(map[string]bool)
+	for _, feature := range version.GetFeatures() {
+		client.agentFeatures[feature] = true
+	}
+
+	var missing []string
+	for _, feature := range client.requiredFeatures {
+		if !client.agentFeatures[feature] {
+			missing = append(missing, feature)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("agent does not support feature(s): %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// AgentVersion function will return the version of the connected agent. It is empty for agents which do not report
+// their version
+func (client *Client) AgentVersion() string {
+	return client.agentVersion
+}
+
+// HasFeature function will report whether the connected agent supports feature
+func (client *Client) HasFeature(feature string) bool {
+	return client.agentFeatures[feature]
+}